@@ -0,0 +1,268 @@
+// Package changelog provides common functionality for managing changelogs.
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Processor is an abstraction for reading and writing changelogs.
+type Processor interface {
+	Parse(ParseOptions) (*Changelog, error)
+	Render(*Changelog) (string, error)
+}
+
+// ParseOptions determines how a changelog file should be parsed.
+type ParseOptions struct{}
+
+// Changelog represents the entire changelog of a repository.
+// Unreleased is the release for all changes after the last tag and it is rewritten on every run.
+// Malformed are the release headings in the changelog file that cannot be parsed and are skipped.
+type Changelog struct {
+	Title      string
+	Unreleased *Release
+	New        []Release
+	Existing   []Release
+	Malformed  []Heading
+}
+
+// Heading represents a heading in a changelog file.
+type Heading struct {
+	Line int
+	Text string
+}
+
+// Release represents a single release of a repository in a changelog.
+// TagMessage is the message of an annotated tag and Summary is a prose description of the release, both in Markdown format.
+// BreakingChanges are rendered before any group of changes.
+// ChangeGroups are used instead of IssueGroups and MergeGroups when issues and pull/merge requests are grouped together.
+// Commits are the commits pushed directly to the branch without pull/merge requests.
+// Stats are the metrics of the release and they are only resolved if enabled.
+type Release struct {
+	TagName         string
+	TagURL          string
+	TagTime         time.Time
+	ReleaseURL      string
+	CompareURL      string
+	TagMessage      string
+	Summary         string
+	BreakingChanges []BreakingChange
+	IssueGroups     []IssueGroup
+	MergeGroups     []MergeGroup
+	ChangeGroups    []ChangeGroup
+	Commits         []Commit
+	Contributors    []Contributor
+	Stats           *Stats
+}
+
+// Stats represents the metrics of a release.
+// Groups are the number of issues and pull/merge requests in the top-level groups of the release.
+// Days is the number of days since the Previous release (if any).
+// LeadTime is the median time from opening to merging pull/merge requests and TimeToClose is the median time from opening to closing issues.
+type Stats struct {
+	Issues       int
+	Merges       int
+	Groups       []GroupStats
+	Contributors int
+	Previous     string
+	Days         int
+	LeadTime     time.Duration
+	TimeToClose  time.Duration
+}
+
+// GroupStats represents the number of issues and pull/merge requests in a group.
+type GroupStats struct {
+	Title  string
+	Issues int
+	Merges int
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// formatDuration formats a duration in days and hours, or in hours and minutes if shorter than a day.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days, hours, minutes := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour), int(d%time.Hour/time.Minute)
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// String returns a single line of the metrics (i.e. 2 issues, 3 pull/merge requests, 2 contributors, 14 days since v0.1.0, ...).
+// The metrics that are not known are left out.
+func (s Stats) String() string {
+	metrics := []string{
+		plural(s.Issues, "issue", "issues"),
+		plural(s.Merges, "pull/merge request", "pull/merge requests"),
+		plural(s.Contributors, "contributor", "contributors"),
+	}
+
+	if s.Previous != "" {
+		metrics = append(metrics, fmt.Sprintf("%s since %s", plural(s.Days, "day", "days"), s.Previous))
+	}
+
+	if s.LeadTime > 0 {
+		metrics = append(metrics, "median lead time "+formatDuration(s.LeadTime))
+	}
+
+	if s.TimeToClose > 0 {
+		metrics = append(metrics, "median time to close "+formatDuration(s.TimeToClose))
+	}
+
+	return strings.Join(metrics, ", ")
+}
+
+// BreakingChange represents an issue or a pull/merge request with a breaking change.
+// Notes are the migration notes extracted from the description or the commit message in Markdown format.
+type BreakingChange struct {
+	Number int
+	Title  string
+	URL    string
+	Notes  string
+}
+
+// IssueGroup represents a group of issues.
+// A collapsed group is rendered as a single entry.
+// A group with subgroups has its issues in the subgroups.
+type IssueGroup struct {
+	Title     string
+	Emoji     string
+	Issues    []Issue
+	Collapsed bool
+	Subgroups []IssueGroup
+}
+
+// Issue represents a single issue.
+// Merges are the pull/merge requests listed along with the issue.
+// Tickets are the issues in external issue trackers referenced by the issue.
+// Prerelease is the pre-release tag that first shipped the issue if it is rolled up into a final release.
+type Issue struct {
+	Number     int
+	Title      string
+	URL        string
+	OpenedBy   User
+	ClosedBy   User
+	Merges     []Reference
+	Tickets    []Ticket
+	Prerelease string
+}
+
+// MergeGroup represents a group of pull/merge requests.
+// A collapsed group is rendered as a single entry.
+// A group with subgroups has its pull/merge requests in the subgroups.
+type MergeGroup struct {
+	Title     string
+	Emoji     string
+	Merges    []Merge
+	Collapsed bool
+	Subgroups []MergeGroup
+}
+
+// Merge represents a single pull/merge request.
+// Tickets are the issues in external issue trackers referenced by the pull/merge request.
+// Prerelease is the pre-release tag that first shipped the pull/merge request if it is rolled up into a final release.
+type Merge struct {
+	Number     int
+	Title      string
+	URL        string
+	OpenedBy   User
+	MergedBy   User
+	Tickets    []Ticket
+	Prerelease string
+}
+
+// ChangeKind determines whether a change is an issue or a pull/merge request.
+type ChangeKind string
+
+const (
+	// ChangeKindIssue is the kind for issues.
+	ChangeKindIssue = ChangeKind("issue")
+	// ChangeKindMerge is the kind for pull/merge requests.
+	ChangeKindMerge = ChangeKind("merge")
+)
+
+// ChangeGroup represents a group of issues and pull/merge requests.
+// A collapsed group is rendered as a single entry.
+// A group with subgroups has its changes in the subgroups.
+type ChangeGroup struct {
+	Title     string
+	Emoji     string
+	Changes   []Change
+	Collapsed bool
+	Subgroups []ChangeGroup
+}
+
+// Change represents a single issue or pull/merge request.
+// ClosedBy is the user who closed an issue or merged a pull/merge request.
+// Merges are the pull/merge requests listed along with an issue.
+// Tickets are the issues in external issue trackers referenced by the change.
+// Prerelease is the pre-release tag that first shipped the change if it is rolled up into a final release.
+type Change struct {
+	Kind       ChangeKind
+	Number     int
+	Title      string
+	URL        string
+	OpenedBy   User
+	ClosedBy   User
+	Merges     []Reference
+	Tickets    []Ticket
+	Prerelease string
+}
+
+// Commit represents a single commit.
+// Title is the first line of the commit message.
+type Commit struct {
+	Hash   string
+	Title  string
+	URL    string
+	Author User
+}
+
+// Reference represents a reference to an issue or a pull/merge request.
+type Reference struct {
+	Number int
+	URL    string
+}
+
+// Ticket represents a reference to an issue in an external issue tracker (i.e. PROJ-1234 in Jira).
+type Ticket struct {
+	Key string
+	URL string
+}
+
+// User represents a user.
+type User struct {
+	Name     string
+	Username string
+	URL      string
+}
+
+// Contributor represents a user who contributed to a release.
+// FirstTime is true if the release has the first contribution of the user.
+type Contributor struct {
+	User
+	FirstTime bool
+}
+
+// NewChangelog creates a new empty default changelog.
+func NewChangelog() *Changelog {
+	return &Changelog{
+		Title: "Changelog",
+	}
+}
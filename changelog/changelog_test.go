@@ -0,0 +1,81 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChangelog(t *testing.T) {
+	changelog := NewChangelog()
+
+	assert.NotNil(t, changelog)
+	assert.NotNil(t, changelog.Title)
+	assert.Len(t, changelog.New, 0)
+	assert.Len(t, changelog.Existing, 0)
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name           string
+		d              time.Duration
+		expectedString string
+	}{
+		{"Minutes", 45 * time.Minute, "45m"},
+		{"Hours", 5 * time.Hour, "5h"},
+		{"HoursAndMinutes", 5*time.Hour + 30*time.Minute, "5h 30m"},
+		{"Days", 48 * time.Hour, "2d"},
+		{"DaysAndHours", 52*time.Hour + 10*time.Minute, "2d 4h"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, formatDuration(tc.d))
+		})
+	}
+}
+
+func TestStats_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              Stats
+		expectedString string
+	}{
+		{
+			name:           "Empty",
+			s:              Stats{},
+			expectedString: "0 issues, 0 pull/merge requests, 0 contributors",
+		},
+		{
+			name: "Singular",
+			s: Stats{
+				Issues:       1,
+				Merges:       1,
+				Contributors: 1,
+				Previous:     "v0.1.0",
+				Days:         1,
+			},
+			expectedString: "1 issue, 1 pull/merge request, 1 contributor, 1 day since v0.1.0",
+		},
+		{
+			name: "All",
+			s: Stats{
+				Issues:       2,
+				Merges:       3,
+				Contributors: 2,
+				Previous:     "v0.1.0",
+				Days:         14,
+				LeadTime:     52 * time.Hour,
+				TimeToClose:  6 * time.Hour,
+			},
+			expectedString: "2 issues, 3 pull/merge requests, 2 contributors, 14 days since v0.1.0, median lead time 2d 4h, median time to close 6h",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.s.String())
+		})
+	}
+}
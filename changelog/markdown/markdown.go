@@ -0,0 +1,410 @@
+// Package markdown provides functionality to process changelogs in Markdown format.
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+)
+
+const timeLayout = "2006-01-02"
+
+const emptyTemplate = `# {{title .Title}}
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+`
+
+// Groups with subgroups are rendered as headings starting from level 3 (releases are level 2 headings).
+// The Unreleased section is rendered before the new releases and it has no date.
+const changelogTemplate = `{{define "issueGroups"}}{{$level := .Level}}{{range .Groups}}{{if .Subgroups}}{{heading $level}} {{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}
+
+{{template "issueGroups" (nested .Subgroups (inc $level))}}{{else if .Collapsed}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}} ({{len .Issues}}):** {{range $i, $e := .Issues}}{{if $i}}, {{end}}[#{{.Number}}]({{.URL}}){{end}}
+
+{{else}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}:**
+
+{{range .Issues}}  - {{.Title}} [#{{.Number}}]({{.URL}}){{range .Merges}}, [#{{.Number}}]({{.URL}}){{end}}{{range .Tickets}}, [{{.Key}}]({{.URL}}){{end}} ({{if ne .OpenedBy.Username .ClosedBy.Username}}[{{.OpenedBy.Username}}]({{.OpenedBy.URL}}), {{end}}[{{.ClosedBy.Username}}]({{.ClosedBy.URL}})){{with .Prerelease}} (since {{.}}){{end}}
+{{end}}
+{{end}}{{end}}{{end}}{{define "mergeGroups"}}{{$level := .Level}}{{range .Groups}}{{if .Subgroups}}{{heading $level}} {{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}
+
+{{template "mergeGroups" (nested .Subgroups (inc $level))}}{{else if .Collapsed}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}} ({{len .Merges}}):** {{range $i, $e := .Merges}}{{if $i}}, {{end}}[#{{.Number}}]({{.URL}}){{end}}
+
+{{else}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}:**
+
+{{range .Merges}}  - {{.Title}} [#{{.Number}}]({{.URL}}){{range .Tickets}}, [{{.Key}}]({{.URL}}){{end}} ({{if ne .OpenedBy.Username .MergedBy.Username}}[{{.OpenedBy.Username}}]({{.OpenedBy.URL}}), {{end}}[{{.MergedBy.Username}}]({{.MergedBy.URL}})){{with .Prerelease}} (since {{.}}){{end}}
+{{end}}
+{{end}}{{end}}{{end}}{{define "changeGroups"}}{{$level := .Level}}{{range .Groups}}{{if .Subgroups}}{{heading $level}} {{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}
+
+{{template "changeGroups" (nested .Subgroups (inc $level))}}{{else if .Collapsed}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}} ({{len .Changes}}):** {{range $i, $e := .Changes}}{{if $i}}, {{end}}[#{{.Number}}]({{.URL}}){{end}}
+
+{{else}}**{{if .Emoji}}{{.Emoji}} {{end}}{{title .Title}}:**
+
+{{range .Changes}}  - {{.Title}} [#{{.Number}}]({{.URL}}){{range .Merges}}, [#{{.Number}}]({{.URL}}){{end}}{{range .Tickets}}, [{{.Key}}]({{.URL}}){{end}} ({{if ne .OpenedBy.Username .ClosedBy.Username}}[{{.OpenedBy.Username}}]({{.OpenedBy.URL}}), {{end}}[{{.ClosedBy.Username}}]({{.ClosedBy.URL}})){{with .Prerelease}} (since {{.}}){{end}}
+{{end}}
+{{end}}{{end}}{{end}}{{define "release"}}{{if .ReleaseURL}}
+{{.ReleaseURL}}
+{{end}}
+[Compare Changes]({{.CompareURL}})
+
+{{with summary .TagMessage}}{{.}}
+
+{{end}}{{with summary .Summary}}{{.}}
+
+{{end}}{{if .BreakingChanges}}**:warning: Breaking Changes:**
+
+{{range .BreakingChanges}}  - {{.Title}} [#{{.Number}}]({{.URL}}){{with notes .Notes}}
+{{.}}{{end}}
+{{end}}
+{{end}}{{template "issueGroups" (nested .IssueGroups 3)}}{{template "mergeGroups" (nested .MergeGroups 3)}}{{template "changeGroups" (nested .ChangeGroups 3)}}{{if .Commits}}**Other Commits:**
+
+{{range .Commits}}  - {{.Title}} [{{short .Hash}}]({{.URL}}) ({{if .Author.Username}}[{{.Author.Username}}]({{.Author.URL}}){{else}}{{.Author.Name}}{{end}})
+{{end}}
+{{end}}{{if .Contributors}}**Contributors:** {{range $i, $c := .Contributors}}{{if $i}}, {{end}}{{if .FirstTime}}**{{end}}{{if .Username}}[@{{.Username}}]({{.URL}}){{else}}{{escapeCommas .Name}}{{end}}{{if .FirstTime}}** (first contribution){{end}}{{end}}
+
+{{end}}{{with .Stats}}**Stats:** {{.}}
+
+{{end}}
+{{end}}{{with .Unreleased}}## [Unreleased]({{.TagURL}})
+{{template "release" .}}{{end}}{{range .New}}## [{{.TagName}}]({{.TagURL}}) ({{time .TagTime}})
+{{template "release" .}}{{end}}`
+
+var (
+	h1Regex = regexp.MustCompile(`^# ([0-9A-Za-z-_]+)$`)
+	h2Regex = regexp.MustCompile(`^## \[([0-9A-Za-z-.]+)\]\(([0-9A-Za-z-.:/]+)\) \((\d{4}-\d{2}-\d{2})\)$`)
+
+	unreleasedRegex = regexp.MustCompile(`^## \[Unreleased\]\((\S+)\)$`)
+	compareRegex    = regexp.MustCompile(`^\[Compare Changes\]\((\S+)\)$`)
+
+	contributorsRegex = regexp.MustCompile(`^\*\*Contributors:\*\* (.+)$`)
+	userLinkRegex     = regexp.MustCompile(`^\[@(.+)\]\((\S+)\)$`)
+
+	// Regexes for sanitizing summaries
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagRegex     = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+	headingRegex     = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t#]*$`)
+	blankLinesRegex  = regexp.MustCompile(`\n{3,}`)
+
+	funcMap = template.FuncMap{
+		"title": strings.Title, // nolint directives: sa1019
+		"time": func(t time.Time) string {
+			return t.Format(timeLayout)
+		},
+		"summary": func(text string) template.HTML {
+			// The summary is sanitized, so it can be rendered as is
+			return template.HTML(sanitize(text))
+		},
+		"notes": func(text string) template.HTML {
+			// The notes are sanitized and indented, so they are rendered under their list item
+			return template.HTML(indent(sanitize(text), "    "))
+		},
+		"short": func(hash string) string {
+			if len(hash) > 7 {
+				return hash[:7]
+			}
+			return hash
+		},
+		"escapeCommas": escapeCommas,
+		"heading": func(level int) string {
+			return strings.Repeat("#", level)
+		},
+		"inc": func(n int) int {
+			return n + 1
+		},
+		"nested": func(groups interface{}, level int) map[string]interface{} {
+			return map[string]interface{}{
+				"Groups": groups,
+				"Level":  level,
+			}
+		},
+	}
+)
+
+// sanitize sanitizes a Markdown text, so it can be safely embedded in a release section.
+// HTML comments and tags are removed and headings are converted to bold texts, so they do not break the changelog structure.
+func sanitize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = htmlCommentRegex.ReplaceAllString(text, "")
+	text = htmlTagRegex.ReplaceAllString(text, "")
+	text = headingRegex.ReplaceAllString(text, "**$1**")
+	text = blankLinesRegex.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text)
+}
+
+// indent indents every non-empty line of a text with a given prefix.
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// escapeCommas escapes the commas in a name, so it can be told apart from the separators in a rendered list.
+// A backslash-escaped comma is rendered as a plain comma in Markdown.
+func escapeCommas(name string) string {
+	return strings.ReplaceAll(name, ",", `\,`)
+}
+
+// splitList splits a rendered list on the separators and unescapes the commas in every item.
+func splitList(list string) []string {
+	items := []string{}
+
+	var item strings.Builder
+	for i := 0; i < len(list); i++ {
+		switch {
+		case list[i] == '\\' && i+1 < len(list) && list[i+1] == ',':
+			item.WriteByte(',')
+			i++
+		case strings.HasPrefix(list[i:], ", "):
+			items = append(items, item.String())
+			item.Reset()
+			i++
+		default:
+			item.WriteByte(list[i])
+		}
+	}
+
+	return append(items, item.String())
+}
+
+// processor implements the changelog.Processor interface for Markdown format.
+type processor struct {
+	ui            ui.UI
+	baseFile      string
+	changelogFile string
+	content       string
+	transform     Transform
+}
+
+// Transform transforms the rendered content of new releases before it is written to the changelog file.
+type Transform func(chlog *changelog.Changelog, content string) (string, error)
+
+// Option sets an optional configuration for a Markdown processor.
+type Option func(*processor)
+
+// WithTransform sets a transform for the rendered content of new releases.
+func WithTransform(t Transform) Option {
+	return func(p *processor) {
+		p.transform = t
+	}
+}
+
+// NewProcessor creates a new changelog processor for Markdown format.
+func NewProcessor(ui ui.UI, baseFile, changelogFile string, opts ...Option) changelog.Processor {
+	p := &processor{
+		ui:            ui,
+		baseFile:      baseFile,
+		changelogFile: changelogFile,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *processor) createChangelog() (*changelog.Changelog, error) {
+	chlog := changelog.NewChangelog()
+
+	tmpl, _ := template.New("changelog").Funcs(funcMap).Parse(emptyTemplate)
+	buf := new(bytes.Buffer)
+	_ = tmpl.Execute(buf, chlog)
+	p.content = buf.String()
+
+	p.ui.Warnf(ui.Yellow, "%s not found", p.changelogFile)
+	p.ui.Infof(ui.Green, "A new changelog is created.")
+
+	return chlog, nil
+}
+
+// parseContributors parses a rendered list of contributors.
+func parseContributors(list string) []changelog.Contributor {
+	contributors := []changelog.Contributor{}
+
+	for _, item := range splitList(list) {
+		var firstTime bool
+		if strings.HasSuffix(item, " (first contribution)") {
+			item = strings.TrimSuffix(item, " (first contribution)")
+			item = strings.Trim(item, "*")
+			firstTime = true
+		}
+
+		var user changelog.User
+		if sm := userLinkRegex.FindStringSubmatch(item); len(sm) == 3 {
+			user.Username = sm[1]
+			user.URL = sm[2]
+		} else {
+			user.Name = item
+		}
+
+		contributors = append(contributors, changelog.Contributor{
+			User:      user,
+			FirstTime: firstTime,
+		})
+	}
+
+	return contributors
+}
+
+func (p *processor) Parse(opts changelog.ParseOptions) (*changelog.Changelog, error) {
+	p.ui.Debugf(ui.Cyan, "Opening %s ...", p.changelogFile)
+
+	f, err := os.Open(p.changelogFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p.createChangelog()
+		}
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	p.ui.Debugf(ui.Cyan, "Parsing %s ...", p.changelogFile)
+
+	content := ""
+	chlog := new(changelog.Changelog)
+
+	// The Unreleased section is removed from the content, so it can be replaced by the new one
+	var inUnreleased bool
+
+	var lineNum int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+
+		if sm := unreleasedRegex.FindStringSubmatch(line); len(sm) == 2 {
+			chlog.Unreleased = &changelog.Release{
+				TagName: "Unreleased",
+				TagURL:  sm[1],
+			}
+			inUnreleased = true
+			continue
+		} else if inUnreleased && !strings.HasPrefix(line, "## ") {
+			continue
+		}
+
+		inUnreleased = false
+		content += fmt.Sprintln(line)
+
+		if sm := h1Regex.FindStringSubmatch(line); len(sm) == 2 {
+			chlog.Title = sm[1]
+		} else if sm := h2Regex.FindStringSubmatch(line); len(sm) == 4 {
+			t, err := time.Parse(timeLayout, sm[3])
+			if err != nil {
+				return nil, err
+			}
+
+			chlog.Existing = append(chlog.Existing, changelog.Release{
+				TagName: sm[1],
+				TagURL:  sm[2],
+				TagTime: t,
+			})
+		} else if strings.HasPrefix(line, "## ") {
+			// Releases are the only level 2 headings, so any other one is a malformed release heading
+			chlog.Malformed = append(chlog.Malformed, changelog.Heading{
+				Line: lineNum,
+				Text: line,
+			})
+		} else if sm := compareRegex.FindStringSubmatch(line); len(sm) == 2 {
+			// Only the first link after a release heading belongs to the release
+			if l := len(chlog.Existing); l > 0 && chlog.Existing[l-1].CompareURL == "" {
+				chlog.Existing[l-1].CompareURL = sm[1]
+			}
+		} else if sm := contributorsRegex.FindStringSubmatch(line); len(sm) == 2 {
+			if l := len(chlog.Existing); l > 0 {
+				chlog.Existing[l-1].Contributors = parseContributors(sm[1])
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	p.content = content
+
+	p.ui.Infof(ui.Green, "Successfully parsed %s", p.changelogFile)
+
+	return chlog, nil
+}
+
+func (p *processor) Render(chlog *changelog.Changelog) (string, error) {
+	p.ui.Debugf(ui.Cyan, "Updating the changelog ...")
+
+	// ==============================> RENDER THE CONTENT FOR NEW RELEASES <==============================
+
+	// All parameters are pre-defined and we do not expect an error here
+	tmpl, _ := template.New("changelog").Funcs(funcMap).Parse(changelogTemplate)
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, chlog); err != nil {
+		return "", err
+	}
+
+	newContent := buf.String()
+
+	if p.transform != nil {
+		var err error
+		if newContent, err = p.transform(chlog, newContent); err != nil {
+			return "", err
+		}
+	}
+
+	// ==============================> UPDATE THE CHANGELOG FILE <==============================
+
+	// The changelog may shrink when the Unreleased section is replaced
+	f, err := os.OpenFile(p.changelogFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if i := strings.Index(p.content, "##"); i >= 0 {
+		p.content = p.content[:i] + newContent + p.content[i:]
+	} else {
+		// Add the content of an optional base file if generating the changelog for the first time
+		var baseContent string
+		if p.baseFile != "" {
+			p.ui.Infof(ui.Green, "Adding the base file content to the changelog: %s", p.baseFile)
+
+			b, err := os.ReadFile(p.baseFile)
+			if err != nil {
+				return "", err
+			}
+
+			baseContent = string(b)
+		}
+
+		p.content += newContent + baseContent
+	}
+
+	if _, err = f.WriteString(p.content); err != nil {
+		return "", err
+	}
+
+	p.ui.Infof(ui.Green, "Successfully updated the changelog: %s", p.changelogFile)
+
+	return newContent, nil
+}
@@ -0,0 +1,892 @@
+package markdown
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+)
+
+var (
+	tagTime, _ = time.Parse(time.RFC3339, "2020-11-02T22:00:00-04:00")
+	chlog      = &changelog.Changelog{
+		New: []changelog.Release{
+			{
+				TagName:    "v0.2.0",
+				TagURL:     "https://github.com/octocat/Hello-World/tree/v0.2.0",
+				TagTime:    tagTime,
+				ReleaseURL: "https://storage.artifactory.com/project/releases/v0.2.0",
+				CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0",
+				IssueGroups: []changelog.IssueGroup{
+					{
+						Title: "Fixed Bugs",
+						Emoji: ":bug:",
+						Issues: []changelog.Issue{
+							{
+								Number: 1001,
+								Title:  "Fixed a bug",
+								URL:    "https://github.com/octocat/Hello-World/issues/1001",
+								OpenedBy: changelog.User{
+									Name:     "The Octocat",
+									Username: "octocat",
+									URL:      "https://github.com/octocat",
+								},
+								ClosedBy: changelog.User{
+									Name:     "The Octocat",
+									Username: "octocat",
+									URL:      "https://github.com/octocat",
+								},
+								Merges: []changelog.Reference{
+									{
+										Number: 1003,
+										URL:    "https://github.com/octocat/Hello-World/pull/1003",
+									},
+								},
+							},
+						},
+					},
+				},
+				MergeGroups: []changelog.MergeGroup{
+					{
+						Title: "Merged Changes",
+						Merges: []changelog.Merge{
+							{
+								Number: 1002,
+								Title:  "Add a feature",
+								URL:    "https://github.com/octocat/Hello-World/pull/1002",
+								OpenedBy: changelog.User{
+									Name:     "The Octocat",
+									Username: "octocat",
+									URL:      "https://github.com/octocat",
+								},
+								MergedBy: changelog.User{
+									Name:     "The Octodog",
+									Username: "octodog",
+									URL:      "https://github.com/octodog",
+								},
+								Tickets: []changelog.Ticket{
+									{
+										Key: "PROJ-1234",
+										URL: "https://example.atlassian.net/browse/PROJ-1234",
+									},
+								},
+							},
+						},
+					},
+					{
+						Title:     "Dependency Updates",
+						Collapsed: true,
+						Merges: []changelog.Merge{
+							{
+								Number: 1004,
+								Title:  "Bump a dependency",
+								URL:    "https://github.com/octocat/Hello-World/pull/1004",
+							},
+							{
+								Number: 1005,
+								Title:  "Bump another dependency",
+								URL:    "https://github.com/octocat/Hello-World/pull/1005",
+							},
+						},
+					},
+				},
+				Contributors: []changelog.Contributor{
+					{
+						User: changelog.User{
+							Name:     "The Octocat",
+							Username: "octocat",
+							URL:      "https://github.com/octocat",
+						},
+					},
+					{
+						User: changelog.User{
+							Name:     "The Octodog",
+							Username: "octodog",
+							URL:      "https://github.com/octodog",
+						},
+						FirstTime: true,
+					},
+				},
+			},
+		},
+	}
+)
+
+var chlogWithSubgroups = &changelog.Changelog{
+	New: []changelog.Release{
+		{
+			TagName:    "v0.2.0",
+			TagURL:     "https://github.com/octocat/Hello-World/tree/v0.2.0",
+			TagTime:    tagTime,
+			CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0",
+			TagMessage: "Release v0.2.0\n\nThe first <b>stable</b> release.\n",
+			MergeGroups: []changelog.MergeGroup{
+				{
+					Title: "Milestone v1.0",
+					Subgroups: []changelog.MergeGroup{
+						{
+							Title: "New Features",
+							Subgroups: []changelog.MergeGroup{
+								{
+									Title: "api",
+									Merges: []changelog.Merge{
+										{
+											Number: 1002,
+											Title:  "Add a feature",
+											URL:    "https://github.com/octocat/Hello-World/pull/1002",
+											OpenedBy: changelog.User{
+												Name:     "The Octocat",
+												Username: "octocat",
+												URL:      "https://github.com/octocat",
+											},
+											MergedBy: changelog.User{
+												Name:     "The Octocat",
+												Username: "octocat",
+												URL:      "https://github.com/octocat",
+											},
+											Prerelease: "v0.2.0-rc.1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Stats: &changelog.Stats{
+				Merges:       1,
+				Contributors: 1,
+				Previous:     "v0.1.0",
+				Days:         23,
+				LeadTime:     30 * time.Hour,
+			},
+		},
+	},
+}
+
+var chlogWithChangeGroups = &changelog.Changelog{
+	New: []changelog.Release{
+		{
+			TagName:    "v0.2.0",
+			TagURL:     "https://github.com/octocat/Hello-World/tree/v0.2.0",
+			TagTime:    tagTime,
+			CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0",
+			Summary:    "<!-- A comment -->\r\n## Highlights\r\n\r\nThis release fixes **two** bugs.",
+			BreakingChanges: []changelog.BreakingChange{
+				{
+					Number: 1003,
+					Title:  "feat!: drop the legacy flag",
+					URL:    "https://github.com/octocat/Hello-World/pull/1003",
+					Notes:  "The `-legacy` flag is removed.\n\nUse the `-compat` flag instead.",
+				},
+				{
+					Number: 1004,
+					Title:  "Removed the v1 API",
+					URL:    "https://github.com/octocat/Hello-World/issues/1004",
+				},
+			},
+			ChangeGroups: []changelog.ChangeGroup{
+				{
+					Title: "Fixed Bugs",
+					Changes: []changelog.Change{
+						{
+							Kind:   changelog.ChangeKindIssue,
+							Number: 1001,
+							Title:  "Fixed a bug",
+							URL:    "https://github.com/octocat/Hello-World/issues/1001",
+							OpenedBy: changelog.User{
+								Name:     "The Octocat",
+								Username: "octocat",
+								URL:      "https://github.com/octocat",
+							},
+							ClosedBy: changelog.User{
+								Name:     "The Octocat",
+								Username: "octocat",
+								URL:      "https://github.com/octocat",
+							},
+						},
+						{
+							Kind:   changelog.ChangeKindMerge,
+							Number: 1002,
+							Title:  "Fixed another bug",
+							URL:    "https://github.com/octocat/Hello-World/pull/1002",
+							OpenedBy: changelog.User{
+								Name:     "The Octocat",
+								Username: "octocat",
+								URL:      "https://github.com/octocat",
+							},
+							ClosedBy: changelog.User{
+								Name:     "The Octodog",
+								Username: "octodog",
+								URL:      "https://github.com/octodog",
+							},
+							Tickets: []changelog.Ticket{
+								{
+									Key: "PROJ-1234",
+									URL: "https://example.atlassian.net/browse/PROJ-1234",
+								},
+							},
+						},
+					},
+				},
+			},
+			Commits: []changelog.Commit{
+				{
+					Hash:  "c414d1004154c6c324bd78c69d10ee101e676059",
+					Title: "Update the README",
+					URL:   "https://github.com/octocat/Hello-World/commit/c414d1004154c6c324bd78c69d10ee101e676059",
+					Author: changelog.User{
+						Name:     "The Octocat",
+						Username: "octocat",
+						URL:      "https://github.com/octocat",
+					},
+				},
+				{
+					Hash:  "20c5fbaa4bd5e7e4ddb86d3bb2b8d6d3f6b7a1b2",
+					Title: "Fix a typo",
+					URL:   "https://github.com/octocat/Hello-World/commit/20c5fbaa4bd5e7e4ddb86d3bb2b8d6d3f6b7a1b2",
+					Author: changelog.User{
+						Name: "The Octodog",
+					},
+				},
+			},
+		},
+	},
+}
+
+var chlogWithUnreleased = &changelog.Changelog{
+	Unreleased: &changelog.Release{
+		TagName:    "HEAD",
+		TagURL:     "https://github.com/octocat/Hello-World/tree/HEAD",
+		CompareURL: "https://github.com/octocat/Hello-World/compare/v0.2.0...HEAD",
+		MergeGroups: []changelog.MergeGroup{
+			{
+				Title: "Merged Changes",
+				Merges: []changelog.Merge{
+					{
+						Number: 1003,
+						Title:  "Add another feature",
+						URL:    "https://github.com/octocat/Hello-World/pull/1003",
+						OpenedBy: changelog.User{
+							Name:     "The Octocat",
+							Username: "octocat",
+							URL:      "https://github.com/octocat",
+						},
+						MergedBy: changelog.User{
+							Name:     "The Octocat",
+							Username: "octocat",
+							URL:      "https://github.com/octocat",
+						},
+					},
+				},
+			},
+		},
+	},
+	New: []changelog.Release{
+		{
+			TagName:    "v0.2.0",
+			TagURL:     "https://github.com/octocat/Hello-World/tree/v0.2.0",
+			TagTime:    tagTime,
+			CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0",
+		},
+	},
+}
+
+const expectedChangelog = `# Changelog
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)
+
+https://storage.artifactory.com/project/releases/v0.2.0
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0)
+
+**:bug: Fixed Bugs:**
+
+  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001), [#1003](https://github.com/octocat/Hello-World/pull/1003) ([octocat](https://github.com/octocat))
+
+**Merged Changes:**
+
+  - Add a feature [#1002](https://github.com/octocat/Hello-World/pull/1002), [PROJ-1234](https://example.atlassian.net/browse/PROJ-1234) ([octocat](https://github.com/octocat), [octodog](https://github.com/octodog))
+
+**Dependency Updates (2):** [#1004](https://github.com/octocat/Hello-World/pull/1004), [#1005](https://github.com/octocat/Hello-World/pull/1005)
+
+**Contributors:** [@octocat](https://github.com/octocat), **[@octodog](https://github.com/octodog)** (first contribution)
+
+
+`
+
+const expectedChangelogWithSubgroups = `# Changelog
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0)
+
+Release v0.2.0
+
+The first stable release.
+
+### Milestone V1.0
+
+#### New Features
+
+**Api:**
+
+  - Add a feature [#1002](https://github.com/octocat/Hello-World/pull/1002) ([octocat](https://github.com/octocat)) (since v0.2.0-rc.1)
+
+**Stats:** 0 issues, 1 pull/merge request, 1 contributor, 23 days since v0.1.0, median lead time 1d 6h
+
+
+`
+
+const expectedChangelogWithChangeGroups = `# Changelog
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0)
+
+**Highlights**
+
+This release fixes **two** bugs.
+
+**:warning: Breaking Changes:**
+
+  - feat!: drop the legacy flag [#1003](https://github.com/octocat/Hello-World/pull/1003)
+    The ` + "`-legacy`" + ` flag is removed.
+
+    Use the ` + "`-compat`" + ` flag instead.
+  - Removed the v1 API [#1004](https://github.com/octocat/Hello-World/issues/1004)
+
+**Fixed Bugs:**
+
+  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001) ([octocat](https://github.com/octocat))
+  - Fixed another bug [#1002](https://github.com/octocat/Hello-World/pull/1002), [PROJ-1234](https://example.atlassian.net/browse/PROJ-1234) ([octocat](https://github.com/octocat), [octodog](https://github.com/octodog))
+
+**Other Commits:**
+
+  - Update the README [c414d10](https://github.com/octocat/Hello-World/commit/c414d1004154c6c324bd78c69d10ee101e676059) ([octocat](https://github.com/octocat))
+  - Fix a typo [20c5fba](https://github.com/octocat/Hello-World/commit/20c5fbaa4bd5e7e4ddb86d3bb2b8d6d3f6b7a1b2) (The Octodog)
+
+
+`
+
+const expectedChangelogWithUnreleased = `# Changelog
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+## [Unreleased](https://github.com/octocat/Hello-World/tree/HEAD)
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.2.0...HEAD)
+
+**Merged Changes:**
+
+  - Add another feature [#1003](https://github.com/octocat/Hello-World/pull/1003) ([octocat](https://github.com/octocat))
+
+
+## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0)
+
+
+`
+
+const expectedChangelogWithBase = `# Changelog
+
+**DO NOT MODIFY THIS FILE!**
+*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
+
+
+## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)
+
+https://storage.artifactory.com/project/releases/v0.2.0
+
+[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.1.0...v0.2.0)
+
+**:bug: Fixed Bugs:**
+
+  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001), [#1003](https://github.com/octocat/Hello-World/pull/1003) ([octocat](https://github.com/octocat))
+
+**Merged Changes:**
+
+  - Add a feature [#1002](https://github.com/octocat/Hello-World/pull/1002), [PROJ-1234](https://example.atlassian.net/browse/PROJ-1234) ([octocat](https://github.com/octocat), [octodog](https://github.com/octodog))
+
+**Dependency Updates (2):** [#1004](https://github.com/octocat/Hello-World/pull/1004), [#1005](https://github.com/octocat/Hello-World/pull/1005)
+
+**Contributors:** [@octocat](https://github.com/octocat), **[@octodog](https://github.com/octodog)** (first contribution)
+
+
+## [v0.1.0](https://github.com/octocat/Hello-World/tree/v0.1.0) (2020-10-10)
+
+`
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		prefix       string
+		expectedText string
+	}{
+		{
+			name:         "Empty",
+			text:         "",
+			prefix:       "    ",
+			expectedText: "",
+		},
+		{
+			name:         "Paragraphs",
+			text:         "First line\nSecond line\n\nSecond paragraph",
+			prefix:       "    ",
+			expectedText: "    First line\n    Second line\n\n    Second paragraph",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedText, indent(tc.text, tc.prefix))
+		})
+	}
+}
+
+func TestEscapeCommas(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expectedText string
+	}{
+		{
+			name:         "NoComma",
+			text:         "The Octocat",
+			expectedText: "The Octocat",
+		},
+		{
+			name:         "Commas",
+			text:         "Doe, Jane, Jr.",
+			expectedText: `Doe\, Jane\, Jr.`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedText, escapeCommas(tc.text))
+		})
+	}
+}
+
+func TestParseContributors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		list                 string
+		expectedContributors []changelog.Contributor
+	}{
+		{
+			name: "Usernames",
+			list: "[@octocat](https://github.com/octocat), **[@octodog](https://github.com/octodog)** (first contribution)",
+			expectedContributors: []changelog.Contributor{
+				{
+					User: changelog.User{Username: "octocat", URL: "https://github.com/octocat"},
+				},
+				{
+					User:      changelog.User{Username: "octodog", URL: "https://github.com/octodog"},
+					FirstTime: true,
+				},
+			},
+		},
+		{
+			name: "NamesWithCommas",
+			list: `Doe\, Jane, [@octocat](https://github.com/octocat), **Smith\, John** (first contribution), The Octofox`,
+			expectedContributors: []changelog.Contributor{
+				{
+					User: changelog.User{Name: "Doe, Jane"},
+				},
+				{
+					User: changelog.User{Username: "octocat", URL: "https://github.com/octocat"},
+				},
+				{
+					User:      changelog.User{Name: "Smith, John"},
+					FirstTime: true,
+				},
+				{
+					User: changelog.User{Name: "The Octofox"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedContributors, parseContributors(tc.list))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expectedText string
+	}{
+		{
+			name:         "Empty",
+			text:         "",
+			expectedText: "",
+		},
+		{
+			name:         "OnlyComment",
+			text:         "<!-- Describe your changes here -->",
+			expectedText: "",
+		},
+		{
+			name:         "HTMLTags",
+			text:         "Use <b>this</b> for a < b<script>alert(1)</script>",
+			expectedText: "Use this for a < balert(1)",
+		},
+		{
+			name:         "Headings",
+			text:         "# Title\n\n## [v1.0.0](https://example.com) (2020-10-10)\n\nSome text ###",
+			expectedText: "**Title**\n\n**[v1.0.0](https://example.com) (2020-10-10)**\n\nSome text ###",
+		},
+		{
+			name:         "BlankLines",
+			text:         "\r\nFirst paragraph\r\n\r\n\r\n\r\nSecond paragraph\r\n",
+			expectedText: "First paragraph\n\nSecond paragraph",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedText, sanitize(tc.text))
+		})
+	}
+}
+
+func TestNewProcessor(t *testing.T) {
+	tests := []struct {
+		name          string
+		ui            ui.UI
+		baseFile      string
+		changelogFile string
+		opts          []Option
+	}{
+		{
+			name:          "OK",
+			ui:            ui.New(ui.Info),
+			baseFile:      "HISTORY.md",
+			changelogFile: "CHANGELOG.md",
+		},
+		{
+			name:          "WithTransform",
+			ui:            ui.New(ui.Info),
+			baseFile:      "HISTORY.md",
+			changelogFile: "CHANGELOG.md",
+			opts: []Option{
+				WithTransform(func(_ *changelog.Changelog, content string) (string, error) {
+					return content, nil
+				}),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProcessor(tc.ui, tc.baseFile, tc.changelogFile, tc.opts...)
+			assert.NotNil(t, p)
+
+			mp, ok := p.(*processor)
+			assert.True(t, ok)
+
+			assert.Equal(t, tc.ui, mp.ui)
+			assert.Equal(t, tc.baseFile, mp.baseFile)
+			assert.Equal(t, tc.changelogFile, mp.changelogFile)
+			assert.Empty(t, mp.content)
+			assert.Equal(t, len(tc.opts) > 0, mp.transform != nil)
+		})
+	}
+}
+
+func TestProcessor_createChangelog(t *testing.T) {
+	tests := []struct {
+		name              string
+		p                 *processor
+		expectedChangelog *changelog.Changelog
+		expectedError     string
+	}{
+		{
+			name: "OK",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chlog, err := tc.p.createChangelog()
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tc.p.content)
+				assert.NotContains(t, tc.p.content, "## [Unreleased]")
+				assert.Equal(t, tc.expectedChangelog, chlog)
+			} else {
+				assert.Nil(t, chlog)
+				assert.Empty(t, tc.p.content)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestProcessor_Parse(t *testing.T) {
+	tests := []struct {
+		name              string
+		p                 *processor
+		opts              changelog.ParseOptions
+		expectedChangelog *changelog.Changelog
+		expectedError     string
+	}{
+		{
+			name: "FileNotExist",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			opts: changelog.ParseOptions{},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success",
+			p: &processor{
+				ui:            ui.NewNop(),
+				changelogFile: "test/CHANGELOG.md",
+			},
+			opts: changelog.ParseOptions{},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+				Existing: []changelog.Release{
+					{
+						TagName: "v0.1.1",
+						TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.1",
+						TagTime: time.Date(2020, time.October, 11, 0, 0, 0, 0, time.UTC),
+						Contributors: []changelog.Contributor{
+							{
+								User: changelog.User{Username: "octocat", URL: "https://github.com/octocat"},
+							},
+							{
+								User:      changelog.User{Username: "octodog", URL: "https://github.com/octodog"},
+								FirstTime: true,
+							},
+							{
+								User: changelog.User{Name: "The Octofox"},
+							},
+						},
+					},
+					{
+						TagName: "v0.1.0",
+						TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.0",
+						TagTime: time.Date(2020, time.October, 10, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "WithUnreleased",
+			p: &processor{
+				ui:            ui.NewNop(),
+				changelogFile: "test/UNRELEASED.md",
+			},
+			opts: changelog.ParseOptions{},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+				Unreleased: &changelog.Release{
+					TagName: "Unreleased",
+					TagURL:  "https://github.com/octocat/Hello-World/tree/HEAD",
+				},
+				Existing: []changelog.Release{
+					{
+						TagName: "v0.1.0",
+						TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.0",
+						TagTime: time.Date(2020, time.October, 10, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "WithMalformed",
+			p: &processor{
+				ui:            ui.NewNop(),
+				changelogFile: "test/MALFORMED.md",
+			},
+			opts: changelog.ParseOptions{},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+				Existing: []changelog.Release{
+					{
+						TagName:    "v0.2.0",
+						TagURL:     "https://github.com/octocat/Hello-World/tree/v0.2.0",
+						TagTime:    time.Date(2020, time.October, 12, 0, 0, 0, 0, time.UTC),
+						CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.2.0",
+					},
+					{
+						TagName:    "v0.1.0",
+						TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.0",
+						TagTime:    time.Date(2020, time.October, 10, 0, 0, 0, 0, time.UTC),
+						CompareURL: "https://github.com/octocat/Hello-World/compare/6dcb09b5b57875f334f61aebed695e2e4193db5e...v0.1.0",
+					},
+				},
+				Malformed: []changelog.Heading{
+					{Line: 7, Text: "## v0.1.1 (2020-10-11)"},
+				},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chlog, err := tc.p.Parse(tc.opts)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tc.p.content)
+				assert.Equal(t, tc.expectedChangelog, chlog)
+			} else {
+				assert.Nil(t, chlog)
+				assert.Empty(t, tc.p.content)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+var errTransform = errors.New("transform failed")
+
+func TestProcessor_Render(t *testing.T) {
+	tests := []struct {
+		name              string
+		p                 *processor
+		chlog             *changelog.Changelog
+		expectedError     error
+		expectedChangelog string
+	}{
+		{
+			name: "WithoutBaseFile",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			chlog:             chlog,
+			expectedError:     nil,
+			expectedChangelog: expectedChangelog,
+		},
+		{
+			name: "WithBaseFile",
+			p: &processor{
+				ui:       ui.NewNop(),
+				baseFile: "test/HISTORY.md",
+			},
+			chlog:             chlog,
+			expectedError:     nil,
+			expectedChangelog: expectedChangelogWithBase,
+		},
+		{
+			name: "WithSubgroups",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			chlog:             chlogWithSubgroups,
+			expectedError:     nil,
+			expectedChangelog: expectedChangelogWithSubgroups,
+		},
+		{
+			name: "WithChangeGroups",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			chlog:             chlogWithChangeGroups,
+			expectedError:     nil,
+			expectedChangelog: expectedChangelogWithChangeGroups,
+		},
+		{
+			name: "WithUnreleased",
+			p: &processor{
+				ui: ui.NewNop(),
+			},
+			chlog:             chlogWithUnreleased,
+			expectedError:     nil,
+			expectedChangelog: expectedChangelogWithUnreleased,
+		},
+		{
+			name: "TransformFails",
+			p: &processor{
+				ui: ui.NewNop(),
+				transform: func(*changelog.Changelog, string) (string, error) {
+					return "", errTransform
+				},
+			},
+			chlog:             chlog,
+			expectedError:     errTransform,
+			expectedChangelog: "",
+		},
+		{
+			name: "WithTransform",
+			p: &processor{
+				ui: ui.NewNop(),
+				transform: func(_ *changelog.Changelog, content string) (string, error) {
+					return strings.Replace(content, "## [v0.2.0]", "## [v0.2.0] :rocket:", 1), nil
+				},
+			},
+			chlog:             chlog,
+			expectedError:     nil,
+			expectedChangelog: strings.Replace(expectedChangelog, "## [v0.2.0]", "## [v0.2.0] :rocket:", 1),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.CreateTemp("", "changelog_test_")
+			assert.NoError(t, err)
+
+			defer func() {
+				assert.NoError(t, os.Remove(f.Name()))
+			}()
+
+			tc.p.changelogFile = f.Name()
+
+			_, err = tc.p.createChangelog()
+			assert.NoError(t, err)
+
+			_, err = tc.p.Render(tc.chlog)
+			assert.Equal(t, tc.expectedError, err)
+
+			b, err := os.ReadFile(tc.p.changelogFile)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedChangelog, string(b))
+		})
+	}
+}
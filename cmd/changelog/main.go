@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/gardenbed/charm/flagit"
 	"github.com/gardenbed/charm/ui"
@@ -14,10 +16,25 @@ import (
 	"github.com/gardenbed/changelog/spec"
 )
 
+const (
+	commandLint  = "lint"
+	commandAudit = "audit"
+	commandStats = "stats"
+)
+
+// exitProblems is the exit status when the lint or audit command finds any problem.
+const exitProblems = 2
+
 func main() {
 	// We will change the verbosity level once it is known
 	u := ui.New(ui.None)
 
+	// The command is the first argument if it is not a flag
+	var command string
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		command = os.Args[1]
+	}
+
 	// READING SPEC
 
 	s, err := spec.Default().FromFile()
@@ -31,9 +48,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := s.Validate(); err != nil {
+		u.Errorf(ui.Red, "%s", err)
+		os.Exit(1)
+	}
+
 	// Update the verbosity level
+	// The output of commands is printed to STDOUT, so only errors are logged (to STDERR)
 	if s.General.Verbose {
 		u.SetLevel(ui.Debug)
+	} else if command != "" {
+		u.SetLevel(ui.Error)
 	} else if !s.General.Print {
 		u.SetLevel(ui.Info)
 	}
@@ -52,23 +77,80 @@ func main() {
 	case s.Version:
 		fmt.Println(metadata.String())
 
-	default:
-		// Retrieve git repo information
+	case command == commandLint:
+		s, g, err := newGenerator(s, u)
+		if err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
 
-		gitRepo, err := git.NewRepo(u, ".")
+		ctx := context.Background()
+
+		problems, err := g.Lint(ctx, s)
 		if err != nil {
 			u.Errorf(ui.Red, "%s", err)
 			os.Exit(1)
 		}
 
-		domain, path, err := gitRepo.GetRemote()
+		if err := printProblems(s, problems); err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+		if len(problems) > 0 {
+			os.Exit(exitProblems)
+		}
+
+	case command == commandAudit:
+		s, g, err := newGenerator(s, u)
 		if err != nil {
 			u.Errorf(ui.Red, "%s", err)
 			os.Exit(1)
 		}
-		s = s.WithRepo(domain, path)
 
-		g, err := generate.New(s, u)
+		ctx := context.Background()
+
+		report, err := g.Audit(ctx, s)
+		if err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+		if err := printReport(s, report); err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+		if len(report.Dropped) > 0 || len(report.Uncategorized) > 0 {
+			os.Exit(exitProblems)
+		}
+
+	case command == commandStats:
+		s, g, err := newGenerator(s, u)
+		if err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+
+		stats, err := g.Stats(ctx, s)
+		if err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+		if err := printStats(s, stats); err != nil {
+			u.Errorf(ui.Red, "%s", err)
+			os.Exit(1)
+		}
+
+	case command != "":
+		u.Errorf(ui.Red, "unknown command: %s", command)
+		os.Exit(1)
+
+	default:
+		s, g, err := newGenerator(s, u)
 		if err != nil {
 			u.Errorf(ui.Red, "%s", err)
 			os.Exit(1)
@@ -82,3 +164,91 @@ func main() {
 		}
 	}
 }
+
+// newGenerator retrieves the git repo information and creates a changelog generator for it.
+// If the repo path is set explicitly, the git remote is not read.
+func newGenerator(s spec.Spec, u ui.UI) (spec.Spec, *generate.Generator, error) {
+	if s.Repo.Path == "" {
+		gitRepo, err := git.NewRepo(u, ".")
+		if err != nil {
+			return s, nil, err
+		}
+
+		domain, path, err := gitRepo.GetRemote(s.Repo.Remote)
+		if err != nil {
+			return s, nil, err
+		}
+		s = s.WithRepo(domain, path)
+	}
+
+	g, err := generate.New(s, u)
+	if err != nil {
+		return s, nil, err
+	}
+
+	return s, g, nil
+}
+
+// printProblems prints the problems found in a changelog file to STDOUT.
+// Each problem is printed in a file:line: rule: message format, or all problems are printed as a JSON array.
+func printProblems(s spec.Spec, problems []generate.LintProblem) error {
+	if s.General.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(problems)
+	}
+
+	for _, p := range problems {
+		location := s.General.File
+		if p.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, p.Line)
+		}
+
+		if p.Tag != "" {
+			fmt.Printf("%s: %s: %s: %s\n", location, p.Rule, p.Tag, p.Message)
+		} else {
+			fmt.Printf("%s: %s: %s\n", location, p.Rule, p.Message)
+		}
+	}
+
+	return nil
+}
+
+// printReport prints an audit report to STDOUT.
+// Each issue or pull/merge request is printed in a kind #number: title (reason) format, or the report is printed in JSON.
+func printReport(s spec.Spec, report *generate.AuditReport) error {
+	if s.General.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	for _, item := range report.Dropped {
+		fmt.Printf("%s #%d: %s (dropped by %s)\n", item.Kind, item.Number, item.Title, item.Stage)
+	}
+
+	for _, item := range report.Uncategorized {
+		fmt.Printf("%s #%d: %s (uncategorized in %s)\n", item.Kind, item.Number, item.Title, item.Group)
+	}
+
+	return nil
+}
+
+// printStats prints the statistics of new releases to STDOUT.
+// Each release is printed in a tag: stats format followed by its top-level groups, or all releases are printed as a JSON array.
+func printStats(s spec.Spec, stats []generate.ReleaseStats) error {
+	if s.General.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
+	for _, r := range stats {
+		fmt.Printf("%s: %s\n", r.Tag, r)
+		for _, g := range r.Groups {
+			fmt.Printf("  %s: %d issues, %d pull/merge requests\n", g.Title, g.Issues, g.Merges)
+		}
+	}
+
+	return nil
+}
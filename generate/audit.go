@@ -0,0 +1,267 @@
+package generate
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+// AuditItem represents an issue or a pull/merge request reported by an audit.
+// Stage is the filtering stage that drops the change (labels, authors, filter, release-notes, or directives).
+// Group is the catch-all group that the change lands in.
+type AuditItem struct {
+	Kind      changelog.ChangeKind `json:"kind"`
+	Number    int                  `json:"number"`
+	Title     string               `json:"title"`
+	URL       string               `json:"url"`
+	Labels    []string             `json:"labels"`
+	Milestone string               `json:"milestone,omitempty"`
+	Stage     string               `json:"stage,omitempty"`
+	Group     string               `json:"group,omitempty"`
+}
+
+// AuditReport is the hygiene report of the issues and pull/merge requests since the last release.
+// Dropped are the changes that would not be in the changelog.
+// Uncategorized are the changes that would land in the catch-all groups (i.e. Closed Issues or Merged Changes).
+type AuditReport struct {
+	Dropped       []AuditItem `json:"dropped"`
+	Uncategorized []AuditItem `json:"uncategorized"`
+}
+
+// Audit reports the closed issues and merged pull/merge requests since the last release in the changelog
+// that would be dropped by the selection and filters or land in the catch-all groups,
+// so their labels and milestones can be fixed before tagging a new release.
+func (g *Generator) Audit(ctx context.Context, s spec.Spec) (*AuditReport, error) {
+	chlog, err := g.processor.Parse(changelog.ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.remoteRepo.CheckPermissions(ctx); err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if len(chlog.Existing) > 0 {
+		since = chlog.Existing[0].TagTime
+	}
+
+	issues, merges, err := g.remoteRepo.FetchIssuesAndMerges(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, merges, err = g.enrichChanges(ctx, s.Jira, issues, merges)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AuditReport{
+		Dropped:       []AuditItem{},
+		Uncategorized: []AuditItem{},
+	}
+
+	for _, stage := range filterStages(s) {
+		selectedIssues, selectedMerges, err := stage.Filter(issues, merges)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range issues {
+			if !containsIssue(selectedIssues, i.Number) {
+				report.Dropped = append(report.Dropped, toAuditItem(changelog.ChangeKindIssue, i.Change, stage.Name, ""))
+			}
+		}
+
+		for _, m := range merges {
+			if !containsMerge(selectedMerges, m.Number) {
+				report.Dropped = append(report.Dropped, toAuditItem(changelog.ChangeKindMerge, m.Change, stage.Name, ""))
+			}
+		}
+
+		issues, merges = selectedIssues, selectedMerges
+	}
+
+	issues, merges = dedupChanges(s.Changes.Dedup, issues, merges)
+
+	// All remaining changes are resolved as a single release, so the changes in the catch-all groups are known
+	tag := remote.Tag{Name: unreleasedTagName}
+	im := issueMap{tag.Name: issues}
+	mm := mergeMap{tag.Name: merges}
+	releases := g.resolveReleases(ctx, s, remote.Tags{tag}, "", im, mm, pushMap{})
+
+	// Issue and merge numbers are not unique together, so they are looked up by kind
+	lookup := func(kind changelog.ChangeKind, num int) remote.Change {
+		if kind == changelog.ChangeKindIssue {
+			for _, i := range issues {
+				if i.Number == num {
+					return i.Change
+				}
+			}
+		} else {
+			for _, m := range merges {
+				if m.Number == num {
+					return m.Change
+				}
+			}
+		}
+		return remote.Change{}
+	}
+
+	add := func(kind changelog.ChangeKind, num int, group string) {
+		report.Uncategorized = append(report.Uncategorized, toAuditItem(kind, lookup(kind, num), "", group))
+	}
+
+	// The catch-all groups can be subgroups of any level for nested groupings
+	auditIssueGroups(releases[0].IssueGroups, len(s.Issues.Grouping.Chain()), "", add)
+	auditMergeGroups(releases[0].MergeGroups, len(s.Merges.Grouping.Chain()), "", add)
+	auditChangeGroups(releases[0].ChangeGroups, len(s.Issues.Grouping.Chain()), "", add)
+
+	g.ui.Infof(ui.Green, "Audited issues and pull/merge requests: %d dropped and %d uncategorized", len(report.Dropped), len(report.Uncategorized))
+
+	return report, nil
+}
+
+// groupPath returns the path of a nested group (i.e. Milestone v1.0 > Closed Issues).
+func groupPath(path, title string) string {
+	if path == "" {
+		return title
+	}
+	return path + " > " + title
+}
+
+// auditIssueGroups walks nested issue groups and calls add for every issue in a catch-all group.
+// A group without subgroups before the last grouping in the chain is not divided by the next grouping,
+// so all of its issues are in the catch-all group of the next grouping (the group for bots is not a catch-all group).
+func auditIssueGroups(groups []changelog.IssueGroup, levels int, path string, add func(changelog.ChangeKind, int, string)) {
+	for _, g := range groups {
+		switch title := groupPath(path, g.Title); {
+		case g.Title == otherIssuesTitle:
+			for _, i := range flattenIssueGroup(g) {
+				add(changelog.ChangeKindIssue, i.Number, title)
+			}
+		case g.Title == botGroupTitle:
+		case len(g.Subgroups) > 0:
+			auditIssueGroups(g.Subgroups, levels-1, title, add)
+		case levels > 1:
+			for _, i := range g.Issues {
+				add(changelog.ChangeKindIssue, i.Number, groupPath(title, otherIssuesTitle))
+			}
+		}
+	}
+}
+
+// auditMergeGroups walks nested merge groups and calls add for every merge in a catch-all group.
+// A group without subgroups before the last grouping in the chain is not divided by the next grouping,
+// so all of its merges are in the catch-all group of the next grouping (the group for bots is not a catch-all group).
+func auditMergeGroups(groups []changelog.MergeGroup, levels int, path string, add func(changelog.ChangeKind, int, string)) {
+	for _, g := range groups {
+		switch title := groupPath(path, g.Title); {
+		case g.Title == otherMergesTitle:
+			for _, m := range flattenMergeGroup(g) {
+				add(changelog.ChangeKindMerge, m.Number, title)
+			}
+		case g.Title == botGroupTitle:
+		case len(g.Subgroups) > 0:
+			auditMergeGroups(g.Subgroups, levels-1, title, add)
+		case levels > 1:
+			for _, m := range g.Merges {
+				add(changelog.ChangeKindMerge, m.Number, groupPath(title, otherMergesTitle))
+			}
+		}
+	}
+}
+
+// auditChangeGroups walks nested change groups and calls add for every change in a catch-all group.
+// A group without subgroups before the last grouping in the chain is not divided by the next grouping,
+// so all of its changes are in the catch-all group of the next grouping (the group for bots is not a catch-all group).
+func auditChangeGroups(groups []changelog.ChangeGroup, levels int, path string, add func(changelog.ChangeKind, int, string)) {
+	for _, g := range groups {
+		switch title := groupPath(path, g.Title); {
+		case g.Title == otherChangesTitle:
+			for _, c := range flattenChangeGroup(g) {
+				add(c.Kind, c.Number, title)
+			}
+		case g.Title == botGroupTitle:
+		case len(g.Subgroups) > 0:
+			auditChangeGroups(g.Subgroups, levels-1, title, add)
+		case levels > 1:
+			for _, c := range g.Changes {
+				add(c.Kind, c.Number, groupPath(title, otherChangesTitle))
+			}
+		}
+	}
+}
+
+// flattenIssueGroup returns the issues in a group and all its subgroups.
+func flattenIssueGroup(g changelog.IssueGroup) []changelog.Issue {
+	issues := append([]changelog.Issue{}, g.Issues...)
+	for _, sg := range g.Subgroups {
+		issues = append(issues, flattenIssueGroup(sg)...)
+	}
+
+	return issues
+}
+
+// flattenMergeGroup returns the merges in a group and all its subgroups.
+func flattenMergeGroup(g changelog.MergeGroup) []changelog.Merge {
+	merges := append([]changelog.Merge{}, g.Merges...)
+	for _, sg := range g.Subgroups {
+		merges = append(merges, flattenMergeGroup(sg)...)
+	}
+
+	return merges
+}
+
+// flattenChangeGroup returns the changes in a group and all its subgroups.
+func flattenChangeGroup(g changelog.ChangeGroup) []changelog.Change {
+	changes := append([]changelog.Change{}, g.Changes...)
+	for _, sg := range g.Subgroups {
+		changes = append(changes, flattenChangeGroup(sg)...)
+	}
+
+	return changes
+}
+
+// containsIssue determines whether or not a list of issues has an issue with a given number.
+func containsIssue(issues remote.Issues, num int) bool {
+	for _, i := range issues {
+		if i.Number == num {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsMerge determines whether or not a list of merges has a merge with a given number.
+func containsMerge(merges remote.Merges, num int) bool {
+	for _, m := range merges {
+		if m.Number == num {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toAuditItem(kind changelog.ChangeKind, c remote.Change, stage, group string) AuditItem {
+	labels := []string{}
+	labels = append(labels, c.Labels...)
+
+	return AuditItem{
+		Kind:      kind,
+		Number:    c.Number,
+		Title:     c.Title,
+		URL:       c.WebURL,
+		Labels:    labels,
+		Milestone: c.Milestone,
+		Stage:     stage,
+		Group:     group,
+	}
+}
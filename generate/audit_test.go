@@ -0,0 +1,268 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+func TestGenerator_Audit(t *testing.T) {
+	issue3 := issue2
+	issue3.Milestone = "v1.0"
+
+	merge3 := merge2
+	merge3.Number = 1005
+	merge3.Milestone = "v2.0"
+	merge3.WebURL = "https://github.com/octocat/Hello-World/pull/1005"
+
+	tests := []struct {
+		name           string
+		g              *Generator
+		ctx            context.Context
+		s              spec.Spec
+		expectedReport *AuditReport
+		expectedError  string
+	}{
+		{
+			name: "ParseFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutError: errors.New("error on parsing the changelog file")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on parsing the changelog file",
+		},
+		{
+			name: "FetchIssuesAndMergesFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{OutError: errors.New("error on fetching issues and merges")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on fetching issues and merges",
+		},
+		{
+			name: "InvalidFilter",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{issue1, issue2},
+							OutMerges: remote.Merges{merge1, merge2},
+						},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Selection: spec.SelectionAll,
+					Filter:    "label:",
+				},
+			},
+			expectedError: `invalid filter expression: expected value after "label:" at position 6`,
+		},
+		{
+			name: "SplitLayout",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Title: "Changelog",
+								Existing: []changelog.Release{
+									{TagName: "v0.1.1", TagTime: t1},
+								},
+							},
+						},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{issue1, issue2},
+							OutMerges: remote.Merges{merge1, merge2},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: ""},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Selection: spec.SelectionAll,
+					Grouping:  spec.GroupingLabel,
+					BugLabels: []string{"bug"},
+				},
+				Merges: spec.Merges{
+					Selection:         spec.SelectionLabeled,
+					Grouping:          spec.GroupingLabel,
+					EnhancementLabels: []string{"enhancement"},
+				},
+				Changes: spec.Changes{
+					Layout: spec.LayoutSplit,
+				},
+			},
+			expectedReport: &AuditReport{
+				Dropped: []AuditItem{
+					{Kind: changelog.ChangeKindMerge, Number: 1004, Title: "Refactored code", URL: "https://github.com/octocat/Hello-World/pull/1004", Labels: []string{}, Stage: "labels"},
+				},
+				Uncategorized: []AuditItem{
+					{Kind: changelog.ChangeKindIssue, Number: 1002, Title: "Discovered a vulnerability", URL: "https://github.com/octocat/Hello-World/issues/1002", Labels: []string{"invalid"}, Group: "Closed Issues"},
+				},
+			},
+		},
+		{
+			name: "UnifiedLayout",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{issue1, issue2},
+							OutMerges: remote.Merges{merge1, merge2},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: ""},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Selection: spec.SelectionAll,
+					Filter:    "!label:invalid",
+					Grouping:  spec.GroupingLabel,
+					BugLabels: []string{"bug"},
+				},
+				Merges: spec.Merges{
+					Selection: spec.SelectionAll,
+				},
+				Changes: spec.Changes{
+					Layout: spec.LayoutUnified,
+				},
+			},
+			expectedReport: &AuditReport{
+				Dropped: []AuditItem{
+					{Kind: changelog.ChangeKindIssue, Number: 1002, Title: "Discovered a vulnerability", URL: "https://github.com/octocat/Hello-World/issues/1002", Labels: []string{"invalid"}, Stage: "filter"},
+				},
+				Uncategorized: []AuditItem{
+					{Kind: changelog.ChangeKindMerge, Number: 1003, Title: "Added a feature", URL: "https://github.com/octocat/Hello-World/pull/1003", Labels: []string{"enhancement"}, Milestone: "v1.0", Group: "Other Changes"},
+					{Kind: changelog.ChangeKindMerge, Number: 1004, Title: "Refactored code", URL: "https://github.com/octocat/Hello-World/pull/1004", Labels: []string{}, Group: "Other Changes"},
+				},
+			},
+		},
+		{
+			name: "NestedGrouping",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{issue1, issue3},
+							OutMerges: remote.Merges{merge1, merge2, merge3},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: ""},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Selection: spec.SelectionAll,
+					Grouping:  spec.Grouping("milestone>label"),
+					BugLabels: []string{"bug"},
+				},
+				Merges: spec.Merges{
+					Selection:         spec.SelectionAll,
+					Grouping:          spec.Grouping("milestone>label"),
+					EnhancementLabels: []string{"enhancement"},
+				},
+				Changes: spec.Changes{
+					Layout: spec.LayoutSplit,
+				},
+			},
+			expectedReport: &AuditReport{
+				Dropped: []AuditItem{},
+				Uncategorized: []AuditItem{
+					{Kind: changelog.ChangeKindIssue, Number: 1002, Title: "Discovered a vulnerability", URL: "https://github.com/octocat/Hello-World/issues/1002", Labels: []string{"invalid"}, Milestone: "v1.0", Group: "Milestone v1.0 > Closed Issues"},
+					{Kind: changelog.ChangeKindMerge, Number: 1005, Title: "Refactored code", URL: "https://github.com/octocat/Hello-World/pull/1005", Labels: []string{}, Milestone: "v2.0", Group: "Milestone v2.0 > Merged Changes"},
+					{Kind: changelog.ChangeKindMerge, Number: 1004, Title: "Refactored code", URL: "https://github.com/octocat/Hello-World/pull/1004", Labels: []string{}, Group: "Merged Changes"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := tc.g.Audit(tc.ctx, tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedReport, report)
+			} else {
+				assert.Nil(t, report)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
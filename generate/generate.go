@@ -3,53 +3,161 @@ package generate
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"regexp"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/gardenbed/charm/ui"
 
-	"github.com/gardenbed/changelog/internal/changelog"
-	"github.com/gardenbed/changelog/internal/changelog/markdown"
-	"github.com/gardenbed/changelog/internal/remote"
-	"github.com/gardenbed/changelog/internal/remote/github"
-	"github.com/gardenbed/changelog/internal/remote/gitlab"
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/changelog/markdown"
+	"github.com/gardenbed/changelog/internal/git"
+	"github.com/gardenbed/changelog/internal/jira"
+	"github.com/gardenbed/changelog/internal/remote/plugin"
+	"github.com/gardenbed/changelog/remote"
 	"github.com/gardenbed/changelog/spec"
 )
 
+// unreleasedTagName is the name of the implicit tag for changes after the last tag.
+const unreleasedTagName = "HEAD"
+
+// Titles of the catch-all groups for changes not selected by any grouping.
+const (
+	otherIssuesTitle  = "Closed Issues"
+	otherMergesTitle  = "Merged Changes"
+	otherChangesTitle = "Other Changes"
+)
+
 // Generator is the changelog generator.
 type Generator struct {
 	ui         ui.UI
+	clock      func() time.Time
+	httpClient *http.Client
+	gitRepo    git.Repo
 	remoteRepo remote.Repo
+	jiraClient *jira.Client
 	processor  changelog.Processor
+	hooks      *hookRunner
+	notifier   *notifier
+}
+
+// Option sets an optional dependency for a changelog generator.
+type Option func(*Generator)
+
+// WithRemote sets the remote repository instead of the one for the platform in the spec.
+// It allows using any implementation of the remote.Repo interface.
+func WithRemote(r remote.Repo) Option {
+	return func(g *Generator) {
+		g.remoteRepo = r
+	}
+}
+
+// WithClock sets the clock for the current time instead of time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(g *Generator) {
+		g.clock = clock
+	}
+}
+
+// WithHTTPClient sets the HTTP client for calling the remote platform and Jira APIs and notifying sinks.
+// Creating a remote repository that does not support custom HTTP clients (i.e. GitHub) fails, unless it is set using WithRemote.
+func WithHTTPClient(client *http.Client) Option {
+	return func(g *Generator) {
+		g.httpClient = client
+	}
+}
+
+// WithProcessor sets the changelog processor instead of the default Markdown processor.
+func WithProcessor(p changelog.Processor) Option {
+	return func(g *Generator) {
+		g.processor = p
+	}
 }
 
 // New creates a new changelog generator.
-func New(s spec.Spec, u ui.UI) (*Generator, error) {
+// By default, the remote repository is created for the platform in the spec using the registered platforms,
+// or using the external provider in the spec if one is set, and changelogs are in Markdown format.
+func New(s spec.Spec, u ui.UI, opts ...Option) (*Generator, error) {
 	if u == nil {
 		u = ui.NewNop()
 	}
 
-	var remoteRepo remote.Repo
-	switch s.Repo.Platform {
-	case spec.PlatformGitHub:
-		parts := strings.Split(s.Repo.Path, "/")
-		if len(parts) != 2 {
-			return nil, errors.New("unexpected GitHub repository: cannot parse owner and repo")
+	g := &Generator{
+		ui: u,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	if g.remoteRepo == nil && s.General.Provider != "" {
+		remoteRepo, err := plugin.NewRepo(u, s.General.Provider, s.Repo.Path, s.Repo.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		g.remoteRepo = remoteRepo
+	}
+
+	// Annotated tags are only needed for tag dates and tag messages
+	annotatedTags := s.Tags.Date == spec.TagDateTag || s.Content.TagMessage
+
+	// The commits of merges are only needed for telling them apart from the commits pushed directly to the branch
+	mergeCommits := s.Commits.Selection == spec.SelectionAll
+
+	if g.remoteRepo == nil {
+		factory, ok := lookupPlatform(s.Repo.Platform)
+		if !ok {
+			return nil, fmt.Errorf("unsupported platform: %s", s.Repo.Platform)
+		}
+
+		remoteRepo, err := factory(RemoteConfig{
+			UI:            u,
+			Path:          s.Repo.Path,
+			AccessToken:   s.Repo.AccessToken,
+			Clock:         g.clock,
+			HTTPClient:    g.httpClient,
+			AnnotatedTags: annotatedTags,
+			MergeCommits:  mergeCommits,
+		})
+
+		if err != nil {
+			return nil, err
 		}
-		remoteRepo = github.NewRepo(u, parts[0], parts[1], s.Repo.AccessToken)
 
-	case spec.PlatformGitLab:
-		remoteRepo = gitlab.NewRepo(u, s.Repo.Path, s.Repo.AccessToken)
+		g.remoteRepo = remoteRepo
 	}
 
-	return &Generator{
-		ui:         u,
-		remoteRepo: remoteRepo,
-		processor:  markdown.NewProcessor(u, s.General.Base, s.General.File),
-	}, nil
+	if s.Jira.URL != "" {
+		g.jiraClient = jira.NewClient(s.Jira.URL, s.Jira.User, s.Jira.Token, g.httpClient)
+	}
+
+	g.hooks = &hookRunner{
+		ui:   u,
+		file: s.General.File,
+	}
+
+	g.notifier = &notifier{
+		ui:     u,
+		client: g.httpClient,
+	}
+
+	if g.processor == nil {
+		g.processor = markdown.NewProcessor(u, s.General.Base, s.General.File)
+	}
+
+	// The remote repository is the primary source of annotated tags.
+	// The local git repository is a fallback for the tags that are not annotated by the remote repository
+	// (i.e. the platform or provider does not support annotated tags).
+	if annotatedTags {
+		if r, err := git.NewRepo(u, "."); err == nil {
+			g.gitRepo = r
+		} else {
+			u.Warnf(ui.Yellow, "Local git repository is not available for annotated tags: %s", err)
+		}
+	}
+
+	return g, nil
 }
 
 // resolveTags determines the new tags that should be added to the changelog.
@@ -93,6 +201,15 @@ func (g *Generator) resolveTags(s spec.Tags, sortedTags remote.Tags, chlog *chan
 		newTags = newTags[i:]
 	}
 
+	// Pre-release tags are rolled up into their final release tags (or the latest pre-release tags), so they do not have their own sections
+	if s.Prerelease == spec.PrereleaseRollup {
+		names := sortedTags.Map(mapFunc)
+		if s.Future != "" {
+			names = append(names, s.Future)
+		}
+		newTags = rollupTags(newTags, names)
+	}
+
 	// Resolve the future tag
 	// The future tag should be the most recent tag (at index zero) if any
 	if future := s.Future; future != "" {
@@ -104,6 +221,13 @@ func (g *Generator) resolveTags(s spec.Tags, sortedTags remote.Tags, chlog *chan
 		newTags = append(remote.Tags{futureTag}, newTags...)
 	}
 
+	// Resolve the unreleased tag
+	// The unreleased tag is an implicit tag for HEAD and it should be the most recent tag (at index zero)
+	if s.Unreleased {
+		unreleasedTag := g.remoteRepo.FutureTag(unreleasedTagName)
+		newTags = append(remote.Tags{unreleasedTag}, newTags...)
+	}
+
 	g.ui.Infof(ui.Green, "Resolved new tags for changelog: %s", newTags.Map(mapFunc))
 
 	return newTags, nil
@@ -126,6 +250,7 @@ func (g *Generator) resolveCommitMap(ctx context.Context, branch remote.Branch,
 			rev.Branch = branch.Name
 		} else {
 			commitMap[c.Hash] = &revisions{
+				Commit: c,
 				Branch: branch.Name,
 			}
 		}
@@ -146,7 +271,8 @@ func (g *Generator) resolveCommitMap(ctx context.Context, branch remote.Branch,
 					rev.Tags = append(rev.Tags, tag.Name)
 				} else {
 					commitMap[c.Hash] = &revisions{
-						Tags: []string{tag.Name},
+						Commit: c,
+						Tags:   []string{tag.Name},
 					}
 				}
 			}
@@ -156,9 +282,253 @@ func (g *Generator) resolveCommitMap(ctx context.Context, branch remote.Branch,
 	return commitMap, nil
 }
 
-func (g *Generator) resolveReleases(ctx context.Context, s spec.Spec, sortedTags remote.Tags, baseRev string, im issueMap, cm mergeMap) []changelog.Release {
+// groupIssues groups issues using a chain of groupings.
+// Every grouping in the chain further groups the issues in each group of the previous grouping.
+// The label groups are only used for grouping by labels.
+func (g *Generator) groupIssues(s spec.Issues, labelGroups []labelGroup, chain []spec.Grouping, issues remote.Issues) []changelog.IssueGroup {
+	groups := []changelog.IssueGroup{}
+	unselected := issues
+
+	add := func(title, emoji string, selected remote.Issues) {
+		issueGroup := toIssueGroup(title, selected)
+		issueGroup.Emoji = emoji
+
+		// Subgroups are only needed if they further divide the issues
+		if len(chain) > 1 {
+			subgroups := g.groupIssues(s, labelGroups, chain[1:], selected)
+			if len(subgroups) > 1 || subgroups[0].Title != otherIssuesTitle {
+				issueGroup.Issues = nil
+				issueGroup.Subgroups = subgroups
+			}
+		}
+
+		groups = append(groups, issueGroup)
+	}
+
+	switch chain[0] {
+	case spec.GroupingMilestone:
+		milestones := issues.Milestones()
+		g.ui.Debugf(ui.Cyan, "Grouping issues by milestones %s ...", milestones)
+
+		for _, milestone := range milestones {
+			f := func(i remote.Issue) bool {
+				return i.Milestone == milestone
+			}
+
+			selected, _ := issues.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(fmt.Sprintf("Milestone %s", milestone), "", selected)
+			}
+		}
+
+	case spec.GroupingLabel:
+		g.ui.Debugf(ui.Cyan, "Grouping issues by labels ...")
+
+		for _, group := range labelGroups {
+			f := func(i remote.Issue) bool {
+				return group.Match(i.Labels)
+			}
+
+			selected, _ := issues.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(group.Title, group.Emoji, selected)
+			}
+		}
+
+	case spec.GroupingScope:
+		scopes := issues.Scopes(s.ScopePrefix)
+		g.ui.Debugf(ui.Cyan, "Grouping issues by scopes %s ...", scopes)
+
+		for _, scope := range scopes {
+			f := func(i remote.Issue) bool {
+				return i.Labels.Any(s.ScopePrefix + scope)
+			}
+
+			selected, _ := issues.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(scope, "", selected)
+			}
+		}
+	}
+
+	if len(unselected) > 0 {
+		add(otherIssuesTitle, "", unselected)
+	}
+
+	return groups
+}
+
+// groupMerges groups merges using a chain of groupings.
+// Every grouping in the chain further groups the merges in each group of the previous grouping.
+// The label groups are only used for grouping by labels.
+func (g *Generator) groupMerges(s spec.Merges, labelGroups []labelGroup, chain []spec.Grouping, merges remote.Merges) []changelog.MergeGroup {
+	groups := []changelog.MergeGroup{}
+	unselected := merges
+
+	add := func(title, emoji string, selected remote.Merges) {
+		mergeGroup := toMergeGroup(title, selected)
+		mergeGroup.Emoji = emoji
+
+		// Subgroups are only needed if they further divide the merges
+		if len(chain) > 1 {
+			subgroups := g.groupMerges(s, labelGroups, chain[1:], selected)
+			if len(subgroups) > 1 || subgroups[0].Title != otherMergesTitle {
+				mergeGroup.Merges = nil
+				mergeGroup.Subgroups = subgroups
+			}
+		}
+
+		groups = append(groups, mergeGroup)
+	}
+
+	switch chain[0] {
+	case spec.GroupingMilestone:
+		milestones := merges.Milestones()
+		g.ui.Debugf(ui.Cyan, "Grouping merges by milestones %s ...", milestones)
+
+		for _, milestone := range milestones {
+			f := func(m remote.Merge) bool {
+				return m.Milestone == milestone
+			}
+
+			selected, _ := merges.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(fmt.Sprintf("Milestone %s", milestone), "", selected)
+			}
+		}
+
+	case spec.GroupingLabel:
+		g.ui.Debugf(ui.Cyan, "Grouping merges by labels ...")
+
+		for _, group := range labelGroups {
+			f := func(m remote.Merge) bool {
+				return group.Match(m.Labels)
+			}
+
+			selected, _ := merges.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(group.Title, group.Emoji, selected)
+			}
+		}
+
+	case spec.GroupingScope:
+		scopes := merges.Scopes(s.ScopePrefix)
+		g.ui.Debugf(ui.Cyan, "Grouping merges by scopes %s ...", scopes)
+
+		for _, scope := range scopes {
+			f := func(m remote.Merge) bool {
+				return m.Labels.Any(s.ScopePrefix + scope)
+			}
+
+			selected, _ := merges.Select(f)
+			_, unselected = unselected.Select(f)
+
+			if len(selected) > 0 {
+				add(scope, "", selected)
+			}
+		}
+	}
+
+	if len(unselected) > 0 {
+		add(otherMergesTitle, "", unselected)
+	}
+
+	return groups
+}
+
+// groupChanges groups issues and merges together using a chain of groupings.
+// Every grouping in the chain further groups the changes in each group of the previous grouping.
+// The label groups are only used for grouping by labels.
+func (g *Generator) groupChanges(s spec.Issues, labelGroups []labelGroup, chain []spec.Grouping, issues remote.Issues, merges remote.Merges) []changelog.ChangeGroup {
+	groups := []changelog.ChangeGroup{}
+	unselectedIssues, unselectedMerges := issues, merges
+
+	add := func(title, emoji string, selectedIssues remote.Issues, selectedMerges remote.Merges) {
+		changeGroup := toChangeGroup(title, selectedIssues, selectedMerges)
+		changeGroup.Emoji = emoji
+
+		// Subgroups are only needed if they further divide the changes
+		if len(chain) > 1 {
+			subgroups := g.groupChanges(s, labelGroups, chain[1:], selectedIssues, selectedMerges)
+			if len(subgroups) > 1 || subgroups[0].Title != otherChangesTitle {
+				changeGroup.Changes = nil
+				changeGroup.Subgroups = subgroups
+			}
+		}
+
+		groups = append(groups, changeGroup)
+	}
+
+	selectAndAdd := func(title, emoji string, fi func(remote.Issue) bool, fm func(remote.Merge) bool) {
+		selectedIssues, _ := issues.Select(fi)
+		_, unselectedIssues = unselectedIssues.Select(fi)
+		selectedMerges, _ := merges.Select(fm)
+		_, unselectedMerges = unselectedMerges.Select(fm)
+
+		if len(selectedIssues) > 0 || len(selectedMerges) > 0 {
+			add(title, emoji, selectedIssues, selectedMerges)
+		}
+	}
+
+	switch chain[0] {
+	case spec.GroupingMilestone:
+		milestones := union(issues.Milestones(), merges.Milestones())
+		g.ui.Debugf(ui.Cyan, "Grouping changes by milestones %s ...", milestones)
+
+		for _, milestone := range milestones {
+			selectAndAdd(fmt.Sprintf("Milestone %s", milestone), "",
+				func(i remote.Issue) bool { return i.Milestone == milestone },
+				func(m remote.Merge) bool { return m.Milestone == milestone },
+			)
+		}
+
+	case spec.GroupingLabel:
+		g.ui.Debugf(ui.Cyan, "Grouping changes by labels ...")
+
+		for _, group := range labelGroups {
+			selectAndAdd(group.Title, group.Emoji,
+				func(i remote.Issue) bool { return group.Match(i.Labels) },
+				func(m remote.Merge) bool { return group.Match(m.Labels) },
+			)
+		}
+
+	case spec.GroupingScope:
+		scopes := union(issues.Scopes(s.ScopePrefix), merges.Scopes(s.ScopePrefix))
+		g.ui.Debugf(ui.Cyan, "Grouping changes by scopes %s ...", scopes)
+
+		for _, scope := range scopes {
+			selectAndAdd(scope, "",
+				func(i remote.Issue) bool { return i.Labels.Any(s.ScopePrefix + scope) },
+				func(m remote.Merge) bool { return m.Labels.Any(s.ScopePrefix + scope) },
+			)
+		}
+	}
+
+	if len(unselectedIssues) > 0 || len(unselectedMerges) > 0 {
+		add(otherChangesTitle, "", unselectedIssues, unselectedMerges)
+	}
+
+	return groups
+}
+
+func (g *Generator) resolveReleases(ctx context.Context, s spec.Spec, sortedTags remote.Tags, baseRev string, im issueMap, cm mergeMap, pm pushMap) []changelog.Release {
 	releases := []changelog.Release{}
 
+	// Label and keyword regexes are compiled once for all releases
+	issueLabelGroups := compileLabelGroups(s.Issues.LabelGroups())
+	mergeLabelGroups := compileLabelGroups(s.Merges.LabelGroups())
+	breakingKeywordRE := compileBreakingKeywords(s.Changes.Breaking.Keywords)
+
 	for i, tag := range sortedTags {
 		releaseURL := s.Content.GetReleaseURL(tag.Name)
 
@@ -178,100 +548,100 @@ func (g *Generator) resolveReleases(ctx context.Context, s spec.Spec, sortedTags
 			CompareURL: compareURL,
 		}
 
-		// Group issues for the current tag
-		if issues, ok := im[tag.Name]; ok {
-			unselected := issues
+		if s.Tags.Date == spec.TagDateTag && tag.IsAnnotated() {
+			release.TagTime = tag.TaggedAt
+		}
 
-			switch s.Issues.Grouping {
-			case spec.GroupingMilestone:
-				milestones := issues.Milestones()
-				g.ui.Debugf(ui.Cyan, "Grouping issues by milestones %s ...", milestones)
+		if s.Content.TagMessage {
+			release.TagMessage = tag.Message
+		}
 
-				for _, milestone := range milestones {
-					f := func(i remote.Issue) bool {
-						return i.Milestone == milestone
-					}
+		allIssues, allMerges := im[tag.Name], cm[tag.Name]
 
-					selected, _ := issues.Select(f)
-					_, unselected = unselected.Select(f)
+		// Changes of pre-release tags are added to their final release tag
+		var origins originMap
+		if s.Tags.Prerelease == spec.PrereleaseRollup {
+			allIssues, allMerges, origins = rollupPrereleases(tag.Name, im, cm)
+		}
 
-					if len(selected) > 0 {
-						title := fmt.Sprintf("Milestone %s", milestone)
-						issueGroup := toIssueGroup(title, selected)
-						release.IssueGroups = append(release.IssueGroups, issueGroup)
-					}
-				}
+		// Changes by bots are grouped separately
+		issues, botIssues := splitBotIssues(s.Issues.Bots, allIssues)
+		merges, botMerges := splitBotMerges(s.Merges.Bots, allMerges)
 
-			case spec.GroupingLabel:
-				g.ui.Debugf(ui.Cyan, "Grouping issues by labels ...")
+		// Changes with breaking labels are listed in the breaking changes of the release instead of the Breaking Changes group
+		if s.Changes.Breaking.Enabled() {
+			issues, merges = excludeLabeled(s.Issues.BreakingLabels, s.Merges.BreakingLabels, issues, merges)
+		}
 
-				for _, group := range s.Issues.LabelGroups() {
-					f := func(i remote.Issue) bool {
-						return i.Labels.Any(group.Labels...)
-					}
+		// Changes with summary labels are rendered in the summary of the release instead of the Release Summary group
+		if s.Content.Summary {
+			issues, merges = excludeLabeled(s.Issues.SummaryLabels, s.Merges.SummaryLabels, issues, merges)
+		}
 
-					selected, _ := issues.Select(f)
-					_, unselected = unselected.Select(f)
+		if s.Changes.Layout == spec.LayoutUnified {
+			// Group issues and merges together for the current tag
+			if len(issues) > 0 || len(merges) > 0 {
+				release.ChangeGroups = g.groupChanges(s.Issues, issueLabelGroups, s.Issues.Grouping.Chain(), issues, merges)
+			}
 
-					if len(selected) > 0 {
-						issueGroup := toIssueGroup(group.Title, selected)
-						release.IssueGroups = append(release.IssueGroups, issueGroup)
-					}
-				}
+			if len(botIssues) > 0 || len(botMerges) > 0 {
+				changeGroup := toChangeGroup(botGroupTitle, botIssues, botMerges)
+				changeGroup.Collapsed = (len(botIssues) == 0 || s.Issues.Bots == spec.BotsCollapse) &&
+					(len(botMerges) == 0 || s.Merges.Bots == spec.BotsCollapse)
+				release.ChangeGroups = append(release.ChangeGroups, changeGroup)
+			}
+		} else {
+			// Group issues for the current tag
+			if len(issues) > 0 {
+				release.IssueGroups = g.groupIssues(s.Issues, issueLabelGroups, s.Issues.Grouping.Chain(), issues)
 			}
 
-			if len(unselected) > 0 {
-				issueGroup := toIssueGroup("Closed Issues", unselected)
+			if len(botIssues) > 0 {
+				issueGroup := toIssueGroup(botGroupTitle, botIssues)
+				issueGroup.Collapsed = s.Issues.Bots == spec.BotsCollapse
 				release.IssueGroups = append(release.IssueGroups, issueGroup)
 			}
-		}
 
-		// Group merges for the current tag
-		if merges, ok := cm[tag.Name]; ok {
-			unselected := merges
-
-			switch s.Merges.Grouping {
-			case spec.GroupingMilestone:
-				milestones := merges.Milestones()
-				g.ui.Debugf(ui.Cyan, "Grouping merges by milestones %s ...", milestones)
+			// Group merges for the current tag
+			if len(merges) > 0 {
+				release.MergeGroups = g.groupMerges(s.Merges, mergeLabelGroups, s.Merges.Grouping.Chain(), merges)
+			}
 
-				for _, milestone := range milestones {
-					f := func(m remote.Merge) bool {
-						return m.Milestone == milestone
-					}
+			if len(botMerges) > 0 {
+				mergeGroup := toMergeGroup(botGroupTitle, botMerges)
+				mergeGroup.Collapsed = s.Merges.Bots == spec.BotsCollapse
+				release.MergeGroups = append(release.MergeGroups, mergeGroup)
+			}
+		}
 
-					selected, _ := merges.Select(f)
-					_, unselected = unselected.Select(f)
+		if s.Tags.PrereleaseOrigin {
+			annotatePrereleases(&release, origins)
+		}
 
-					if len(selected) > 0 {
-						title := fmt.Sprintf("Milestone %s", milestone)
-						mergeGroup := toMergeGroup(title, selected)
-						release.MergeGroups = append(release.MergeGroups, mergeGroup)
-					}
-				}
+		// Commits pushed directly to the branch are listed after all groups
+		commits := pm[tag.Name]
+		if s.Tags.Prerelease == spec.PrereleaseRollup {
+			commits = rollupPrereleaseCommits(tag.Name, pm)
+		}
 
-			case spec.GroupingLabel:
-				g.ui.Debugf(ui.Cyan, "Grouping merges by labels ...")
+		if len(commits) > 0 {
+			release.Commits = toCommits(commits)
+		}
 
-				for _, group := range s.Merges.LabelGroups() {
-					f := func(m remote.Merge) bool {
-						return m.Labels.Any(group.Labels...)
-					}
+		if s.Content.Summary {
+			release.Summary = resolveSummary(s, allIssues, allMerges)
+		}
 
-					selected, _ := merges.Select(f)
-					_, unselected = unselected.Select(f)
+		if s.Changes.Breaking.Enabled() {
+			release.BreakingChanges = resolveBreakingChanges(s, breakingKeywordRE, allIssues, allMerges)
+		}
 
-					if len(selected) > 0 {
-						mergeGroup := toMergeGroup(group.Title, selected)
-						release.MergeGroups = append(release.MergeGroups, mergeGroup)
-					}
-				}
-			}
+		if s.Content.Contributors {
+			release.Contributors = resolveContributors(allIssues, allMerges)
+		}
 
-			if len(unselected) > 0 {
-				mergeGroup := toMergeGroup("Merged Changes", unselected)
-				release.MergeGroups = append(release.MergeGroups, mergeGroup)
-			}
+		if s.Content.Stats {
+			release.Stats = resolveStats(release, allIssues, allMerges)
 		}
 
 		releases = append(releases, release)
@@ -280,16 +650,37 @@ func (g *Generator) resolveReleases(ctx context.Context, s spec.Spec, sortedTags
 	return releases
 }
 
-// Generate generates changelogs for a Git repository.
-func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
+// enrichChanges enriches issues and merges with the Jira tickets they reference if a Jira instance is configured.
+func (g *Generator) enrichChanges(ctx context.Context, s spec.Jira, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+	if g.jiraClient == nil {
+		return issues, merges, nil
+	}
+
+	enricher, err := newTicketEnricher(s, g.jiraClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if issues, merges, err = enricher.Enrich(ctx, issues, merges); err != nil {
+		return nil, nil, err
+	}
+
+	g.ui.Infof(ui.Green, "Enriched issues and pull/merge requests with Jira tickets")
+
+	return issues, merges, nil
+}
+
+// resolve resolves the existing changelog and the new releases for a Git repository.
+// The second return value is false if the changelog is up-to-date and there is nothing to render.
+func (g *Generator) resolve(ctx context.Context, s spec.Spec) (*changelog.Changelog, bool, error) {
 	// Parse the existing changelog if any
 	chlog, err := g.processor.Parse(changelog.ParseOptions{})
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
 	if err := g.remoteRepo.CheckPermissions(ctx); err != nil {
-		return "", err
+		return nil, false, err
 	}
 
 	// ==============================> FETCH RELEASE BRANCH <==============================
@@ -303,37 +694,39 @@ func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
 	}
 
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
 	// ==============================> FETCH AND FILTER TAGS <==============================
 
 	tags, err := g.remoteRepo.FetchTags(ctx)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
-	g.ui.Infof(ui.Green, "Sorting and filtering git tags ...")
-
-	sortedTags := tags.Sort()
-	sortedTags = sortedTags.Exclude(s.Tags.Exclude...)
-
-	if s.Tags.ExcludeRegex != "" {
-		re, err := regexp.CompilePOSIX(s.Tags.ExcludeRegex)
+	if g.gitRepo != nil {
+		annotatedTags, err := g.gitRepo.GetAnnotatedTags()
 		if err != nil {
-			return "", err
+			return nil, false, err
 		}
-		sortedTags = sortedTags.ExcludeRegex(re)
+		tags = annotateTags(tags, annotatedTags)
+	}
+
+	g.ui.Infof(ui.Green, "Sorting and filtering git tags ...")
+
+	sortedTags, err := sortAndFilterTags(s.Tags, tags)
+	if err != nil {
+		return nil, false, err
 	}
 
 	newTags, err := g.resolveTags(s.Tags, sortedTags, chlog)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
 	if len(newTags) == 0 {
 		g.ui.Infof(ui.Green, "Changelog is up-to-date (no new tag or a future tag)")
-		return "", nil
+		return chlog, false, nil
 	}
 
 	// ==============================> RESOLVE GIT REVISION FOR COMPARISON <==============================
@@ -344,7 +737,7 @@ func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
 	} else {
 		firstCommit, err := g.remoteRepo.FetchFirstCommit(ctx)
 		if err != nil {
-			return "", err
+			return nil, false, err
 		}
 		baseRev = firstCommit.Hash
 	}
@@ -355,34 +748,101 @@ func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
 	// We need to resolve the commit map with all sorted tags, so commits will not be misassigned to new tags
 	commitMap, err := g.resolveCommitMap(ctx, branch, sortedTags)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
 	// ==============================> FETCH & ORGANIZE ISSUES AND MERGES <==============================
 
 	// Fetch issues and merges since the last tag on changelog
-	var since time.Time
-	if len(chlog.Existing) > 0 {
-		since = chlog.Existing[0].TagTime
-	}
+	since := resolveSince(chlog.Existing, sortedTags)
 
 	issues, merges, err := g.remoteRepo.FetchIssuesAndMerges(ctx, since)
 	if err != nil {
-		return "", err
+		return nil, false, err
+	}
+
+	// Tickets are resolved before filtering, so ticket types and fix versions can be used for filtering and grouping
+	issues, merges, err = g.enrichChanges(ctx, s.Jira, issues, merges)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sortedIssues, sortedMerges := issues, merges
+	for _, stage := range filterStages(s) {
+		if sortedIssues, sortedMerges, err = stage.Filter(sortedIssues, sortedMerges); err != nil {
+			return nil, false, err
+		}
 	}
 
-	sortedIssues, sortedMerges := filterByLabels(s, issues, merges)
 	g.ui.Infof(ui.Green, "Filtered issues (%d) and pull/merge requests (%d)", len(sortedIssues), len(sortedMerges))
 
+	sortedIssues, sortedMerges = dedupChanges(s.Changes.Dedup, sortedIssues, sortedMerges)
+	g.ui.Debugf(ui.Cyan, "Deduplicated issues (%d) and pull/merge requests (%d)", len(sortedIssues), len(sortedMerges))
+
 	// We need to resolve the issue map with all sorted tags, so issues will not be misassigned to new tags
 	possibleFutureTag := newTags[0]
 	issueMap := resolveIssueMap(sortedIssues, sortedTags, possibleFutureTag)
 	mergeMap := resolveMergeMap(sortedMerges, commitMap, possibleFutureTag)
 	g.ui.Infof(ui.Green, "Partitioned issues and pull/merge requests by tag")
 
-	chlog.New = g.resolveReleases(ctx, s, newTags, baseRev, issueMap, mergeMap)
+	// All merges are used, so the commits of filtered merges are not listed as direct commits
+	pushMap := resolvePushMap(s.Commits, branch, merges, commitMap, possibleFutureTag)
+	g.ui.Debugf(ui.Cyan, "Partitioned direct commits by tag")
+
+	chlog.New = g.resolveReleases(ctx, s, newTags, baseRev, issueMap, mergeMap, pushMap)
 	g.ui.Infof(ui.Green, "Grouped issues and pull/merge requests")
 
+	if s.Content.Contributors {
+		markFirstTimeContributors(chlog.Existing, chlog.New)
+		g.ui.Debugf(ui.Cyan, "Resolved first-time contributors")
+	}
+
+	if s.Content.Stats {
+		resolvePreviousReleases(chlog.Existing, chlog.New)
+		g.ui.Debugf(ui.Cyan, "Resolved release stats")
+	}
+
+	// The Unreleased section is always replaced with the changes after the last tag (if any)
+	chlog.Unreleased = nil
+	if s.Tags.Unreleased {
+		unreleased := chlog.New[0]
+		chlog.New = chlog.New[1:]
+
+		if len(issueMap[unreleasedTagName]) > 0 || len(mergeMap[unreleasedTagName]) > 0 || len(pushMap[unreleasedTagName]) > 0 {
+			unreleased.ReleaseURL = ""
+			chlog.Unreleased = &unreleased
+		}
+	}
+
+	return chlog, true, nil
+}
+
+// Resolve resolves the changelog for a Git repository without rendering it.
+// The new releases (and the Unreleased release if enabled) are resolved from the remote repository,
+// and the existing releases are parsed from the changelog file.
+func (g *Generator) Resolve(ctx context.Context, s spec.Spec) (*changelog.Changelog, error) {
+	chlog, _, err := g.resolve(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return chlog, nil
+}
+
+// Generate generates changelogs for a Git repository.
+// It returns the rendered content for the new releases.
+// The pre-generate, post-render, and post-write hooks in the spec run before rendering, after rendering, and after writing the changelog respectively,
+// and the sinks in the spec are notified of the new releases at the end.
+func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
+	chlog, ok, err := g.resolve(ctx, s)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	if _, err := g.hooks.Run(ctx, hookPreGenerate, s.Hooks.PreGenerate, chlog, ""); err != nil {
+		return "", err
+	}
+
 	// ==============================> UPDATE THE CHANGELOG <==============================
 
 	content, err := g.processor.Render(chlog)
@@ -390,6 +850,30 @@ func (g *Generator) Generate(ctx context.Context, s spec.Spec) (string, error) {
 		return "", err
 	}
 
+	// The post-render hooks run for any processor, so the rendered content is replaced in the changelog file if transformed
+	transformed, err := g.hooks.Run(ctx, hookPostRender, s.Hooks.PostRender, chlog, content)
+	if err != nil {
+		return "", err
+	}
+
+	if transformed != content {
+		if err := replaceContent(s.General.File, content, transformed); err != nil {
+			return "", fmt.Errorf("%s hook: %s", hookPostRender, err)
+		}
+		content = transformed
+	}
+
+	if _, err := g.hooks.Run(ctx, hookPostWrite, s.Hooks.PostWrite, chlog, content); err != nil {
+		return "", err
+	}
+
+	// Sinks are only notified of new releases and not of changes to the Unreleased section
+	if len(chlog.New) > 0 {
+		if err := g.notifier.Notify(ctx, s.Sinks, chlog, content); err != nil {
+			return "", err
+		}
+	}
+
 	if s.General.Print {
 		fmt.Print(content)
 	}
@@ -3,14 +3,19 @@ package generate
 import (
 	"context"
 	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gardenbed/charm/ui"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/gardenbed/changelog/internal/changelog"
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/changelog/markdown"
+	"github.com/gardenbed/changelog/remote"
 	"github.com/gardenbed/changelog/spec"
 )
 
@@ -210,10 +215,13 @@ func parseGitHubTime(s string) time.Time {
 
 func TestNew(t *testing.T) {
 	tests := []struct {
-		name          string
-		s             spec.Spec
-		ui            ui.UI
-		expectedError string
+		name              string
+		s                 spec.Spec
+		ui                ui.UI
+		opts              []Option
+		expectedError     string
+		expectedRemote    remote.Repo
+		expectedProcessor changelog.Processor
 	}{
 		{
 			name: "InvalidSpec",
@@ -226,6 +234,31 @@ func TestNew(t *testing.T) {
 			ui:            nil,
 			expectedError: "unexpected GitHub repository: cannot parse owner and repo",
 		},
+		{
+			name: "UnsupportedPlatform",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.Platform("bitbucket.org"),
+					Path:     "octocat/Hello-World",
+				},
+			},
+			ui:            ui.New(ui.Info),
+			expectedError: "unsupported platform: bitbucket.org",
+		},
+		{
+			name: "ProviderNotFound",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.PlatformGitHub,
+					Path:     "octocat/Hello-World",
+				},
+				General: spec.General{
+					Provider: "unknown",
+				},
+			},
+			ui:            ui.New(ui.Info),
+			expectedError: `provider unknown not found: exec: "changelog-provider-unknown": executable file not found in $PATH`,
+		},
 		{
 			name: "GitHub",
 			s: spec.Spec{
@@ -247,11 +280,68 @@ func TestNew(t *testing.T) {
 			ui:            ui.New(ui.Info),
 			expectedError: "",
 		},
+		{
+			name: "WithClockAndHTTPClient",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.PlatformGitLab,
+				},
+			},
+			ui: ui.New(ui.Info),
+			opts: []Option{
+				WithClock(time.Now),
+				WithHTTPClient(&http.Client{}),
+			},
+			expectedError: "",
+		},
+		{
+			name: "GitHubWithHTTPClient",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.PlatformGitHub,
+					Path:     "octocat/Hello-World",
+				},
+			},
+			ui:            ui.New(ui.Info),
+			opts:          []Option{WithHTTPClient(&http.Client{})},
+			expectedError: "custom HTTP clients are not supported for GitHub repositories",
+		},
+		{
+			name: "WithJira",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.PlatformGitLab,
+				},
+				Jira: spec.Jira{
+					URL: "https://example.atlassian.net",
+				},
+			},
+			ui:            ui.New(ui.Info),
+			opts:          []Option{WithHTTPClient(&http.Client{})},
+			expectedError: "",
+		},
+		{
+			name: "WithOptions",
+			s: spec.Spec{
+				Repo: spec.Repo{
+					Platform: spec.PlatformGitHub,
+					Path:     "octocat/invalid/Hello-World",
+				},
+			},
+			ui: ui.New(ui.Info),
+			opts: []Option{
+				WithRemote(&MockRemoteRepo{}),
+				WithProcessor(&MockChangelogProcessor{}),
+			},
+			expectedError:     "",
+			expectedRemote:    &MockRemoteRepo{},
+			expectedProcessor: &MockChangelogProcessor{},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			g, err := New(tc.s, tc.ui)
+			g, err := New(tc.s, tc.ui, tc.opts...)
 
 			if tc.expectedError != "" {
 				assert.Nil(t, g)
@@ -261,6 +351,17 @@ func TestNew(t *testing.T) {
 				assert.Equal(t, tc.ui, g.ui)
 				assert.NotNil(t, g.remoteRepo)
 				assert.NotNil(t, g.processor)
+				assert.Equal(t, tc.s.Jira.URL != "", g.jiraClient != nil)
+				assert.NotNil(t, g.hooks)
+				assert.NotNil(t, g.notifier)
+
+				if tc.expectedRemote != nil {
+					assert.Equal(t, tc.expectedRemote, g.remoteRepo)
+				}
+
+				if tc.expectedProcessor != nil {
+					assert.Equal(t, tc.expectedProcessor, g.processor)
+				}
 			}
 		})
 	}
@@ -291,6 +392,24 @@ func TestGenerator_resolveTags(t *testing.T) {
 		WebURL: "https://github.com/octocat/Hello-World/tree/v0.4.0",
 	}
 
+	prereleaseTag := remote.Tag{
+		Name:   "v0.2.0-rc.1",
+		Time:   parseGitHubTime("2020-11-03T18:00:00-04:00"),
+		WebURL: "https://github.com/octocat/Hello-World/tree/v0.2.0-rc.1",
+	}
+
+	releaseTag := remote.Tag{
+		Name:   "v0.2.0",
+		Time:   parseGitHubTime("2020-11-04T20:00:00-04:00"),
+		WebURL: "https://github.com/octocat/Hello-World/tree/v0.2.0",
+	}
+
+	unreleasedTag := remote.Tag{
+		Name:   "HEAD",
+		Time:   parseGitHubTime("2020-11-05T22:00:00-04:00"),
+		WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
+	}
+
 	tests := []struct {
 		name          string
 		g             *Generator
@@ -432,6 +551,85 @@ func TestGenerator_resolveTags(t *testing.T) {
 			expectedTags:  remote.Tags{futureTag3, tag2},
 			expectedError: nil,
 		},
+		{
+			name: "NewGitTag_ChangelogTag_Unreleased",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					FutureTagMocks: []FutureTagMock{
+						{OutTag: unreleasedTag},
+					},
+				},
+			},
+			s: spec.Tags{
+				Unreleased: true,
+			},
+			sortedTags: remote.Tags{tag2, tag1},
+			chlog: &changelog.Changelog{
+				Existing: []changelog.Release{
+					{TagName: "v0.1.1"},
+				},
+			},
+			expectedTags:  remote.Tags{unreleasedTag, tag2},
+			expectedError: nil,
+		},
+		{
+			name: "NewGitTags_PrereleaseRollup",
+			g: &Generator{
+				ui: ui.NewNop(),
+			},
+			s: spec.Tags{
+				Prerelease: spec.PrereleaseRollup,
+			},
+			sortedTags: remote.Tags{releaseTag, prereleaseTag, tag2, tag1},
+			chlog: &changelog.Changelog{
+				Existing: []changelog.Release{
+					{TagName: "v0.1.1"},
+				},
+			},
+			expectedTags:  remote.Tags{releaseTag, tag2},
+			expectedError: nil,
+		},
+		{
+			name: "NewGitTags_PrereleaseRollup_NoReleaseTag",
+			g: &Generator{
+				ui: ui.NewNop(),
+			},
+			s: spec.Tags{
+				Prerelease: spec.PrereleaseRollup,
+			},
+			sortedTags: remote.Tags{prereleaseTag, tag2, tag1},
+			chlog: &changelog.Changelog{
+				Existing: []changelog.Release{
+					{TagName: "v0.1.1"},
+				},
+			},
+			expectedTags:  remote.Tags{prereleaseTag, tag2},
+			expectedError: nil,
+		},
+		{
+			name: "NewGitTags_PrereleaseRollup_FutureReleaseTag",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					FutureTagMocks: []FutureTagMock{
+						{OutTag: futureTag2},
+					},
+				},
+			},
+			s: spec.Tags{
+				Future:     "v0.2.0",
+				Prerelease: spec.PrereleaseRollup,
+			},
+			sortedTags: remote.Tags{prereleaseTag, tag2, tag1},
+			chlog: &changelog.Changelog{
+				Existing: []changelog.Release{
+					{TagName: "v0.1.1"},
+				},
+			},
+			expectedTags:  remote.Tags{futureTag2, tag2},
+			expectedError: nil,
+		},
 		{
 			name: "InvalidFromTag",
 			g: &Generator{
@@ -672,13 +870,16 @@ func TestGenerator_resolveCommitMap(t *testing.T) {
 			sortedTags: remote.Tags{tag2, tag1},
 			expectedCommitMap: commitMap{
 				"c414d1004154c6c324bd78c69d10ee101e676059": &revisions{
+					Commit: commit3,
 					Branch: "main",
 				},
 				"0251a422d2038967eeaaaa5c8aa76c7067fdef05": &revisions{
+					Commit: commit2,
 					Branch: "main",
 					Tags:   []string{"v0.1.2"},
 				},
 				"25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378": &revisions{
+					Commit: commit1,
 					Branch: "main",
 					Tags:   []string{"v0.1.2", "v0.1.1"},
 				},
@@ -701,6 +902,67 @@ func TestGenerator_resolveCommitMap(t *testing.T) {
 	}
 }
 
+func TestGenerator_groupIssues(t *testing.T) {
+	issue3 := issue1
+	issue3.Labels = remote.Labels{"bug", "area/api"}
+
+	issue4 := issue1
+	issue4.Labels = remote.Labels{"bug", "area/cli"}
+
+	tests := []struct {
+		name           string
+		g              *Generator
+		s              spec.Issues
+		issues         remote.Issues
+		expectedGroups []changelog.IssueGroup
+	}{
+		{
+			name: "GroupingSimple",
+			g: &Generator{
+				ui: ui.NewNop(),
+			},
+			s: spec.Issues{
+				Grouping: spec.GroupingSimple,
+			},
+			issues: remote.Issues{issue1, issue2},
+			expectedGroups: []changelog.IssueGroup{
+				{Title: "Closed Issues", Issues: []changelog.Issue{changelogIssue1, changelogIssue2}},
+			},
+		},
+		{
+			name: "GroupingLabelScope",
+			g: &Generator{
+				ui: ui.NewNop(),
+			},
+			s: spec.Issues{
+				Grouping:    spec.Grouping("label>scope"),
+				ScopePrefix: "area/",
+				BugLabels:   []string{"bug"},
+			},
+			issues: remote.Issues{issue1, issue3, issue4, issue2},
+			expectedGroups: []changelog.IssueGroup{
+				{
+					Title: "Fixed Bugs",
+					Subgroups: []changelog.IssueGroup{
+						{Title: "api", Issues: []changelog.Issue{changelogIssue1}},
+						{Title: "cli", Issues: []changelog.Issue{changelogIssue1}},
+						{Title: "Closed Issues", Issues: []changelog.Issue{changelogIssue1}},
+					},
+				},
+				{Title: "Closed Issues", Issues: []changelog.Issue{changelogIssue2}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			groups := tc.g.groupIssues(tc.s, compileLabelGroups(tc.s.LabelGroups()), tc.s.Grouping.Chain(), tc.issues)
+
+			assert.Equal(t, tc.expectedGroups, groups)
+		})
+	}
+}
+
 func TestGenerator_resolveReleases(t *testing.T) {
 	now := time.Now()
 
@@ -710,6 +972,51 @@ func TestGenerator_resolveReleases(t *testing.T) {
 		WebURL: "https://github.com/octocat/Hello-World/tree/v0.1.4",
 	}
 
+	annotatedTag := tag3
+	annotatedTag.Message = "Release highlights"
+	annotatedTag.Tagger = user1
+	annotatedTag.TaggedAt = t3.Add(time.Hour)
+
+	directCommit := remote.Commit{
+		Hash:    "20c5414eccaa147f2d6644de4ca36f35293fa43e",
+		Time:    t3,
+		Message: "Update the README\n\nMore details.",
+		Author:  user2,
+		WebURL:  "https://github.com/octocat/Hello-World/commit/20c5414eccaa147f2d6644de4ca36f35293fa43e",
+	}
+
+	bot := remote.User{
+		Username: "dependabot[bot]",
+		WebURL:   "https://github.com/apps/dependabot",
+	}
+
+	botMerge := remote.Merge{
+		Change: remote.Change{
+			Number: 1005,
+			Title:  "Bump a dependency",
+			Time:   t3,
+			Author: bot,
+			WebURL: "https://github.com/octocat/Hello-World/pull/1005",
+		},
+		Merger: user1,
+		Commit: commit3,
+	}
+
+	changelogBotMerge := changelog.Merge{
+		Number: 1005,
+		Title:  "Bump a dependency",
+		URL:    "https://github.com/octocat/Hello-World/pull/1005",
+		OpenedBy: changelog.User{
+			Username: "dependabot[bot]",
+			URL:      "https://github.com/apps/dependabot",
+		},
+		MergedBy: changelog.User{
+			Name:     "The Octocat",
+			Username: "octocat",
+			URL:      "https://github.com/octocat",
+		},
+	}
+
 	tests := []struct {
 		name             string
 		g                *Generator
@@ -719,10 +1026,11 @@ func TestGenerator_resolveReleases(t *testing.T) {
 		baseRev          string
 		issueMap         issueMap
 		mergeMap         mergeMap
+		pushMap          pushMap
 		expectedReleases []changelog.Release
 	}{
 		{
-			name: "WithoutFutureTag_GroupingMilestone",
+			name: "WithoutFutureTag_BotsCollapse",
 			g: &Generator{
 				ui: ui.NewNop(),
 				remoteRepo: &MockRemoteRepo{
@@ -733,48 +1041,38 @@ func TestGenerator_resolveReleases(t *testing.T) {
 			},
 			ctx: context.Background(),
 			s: spec.Spec{
-				Issues: spec.Issues{
-					Grouping: spec.GroupingMilestone,
-				},
 				Merges: spec.Merges{
-					Grouping: spec.GroupingMilestone,
-				},
-				Content: spec.Content{
-					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+					Bots:     spec.BotsCollapse,
+					Grouping: spec.GroupingSimple,
 				},
 			},
 			sortedTags: remote.Tags{tag3},
 			baseRev:    "v0.1.2",
-			issueMap: issueMap{
-				"v0.1.3": remote.Issues{issue1},
-			},
 			mergeMap: mergeMap{
-				"v0.1.3": remote.Merges{merge1},
+				"v0.1.3": remote.Merges{merge1, botMerge},
 			},
 			expectedReleases: []changelog.Release{
 				{
 					TagName:    "v0.1.3",
 					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
 					TagTime:    t3,
-					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.3",
 					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
-					IssueGroups: []changelog.IssueGroup{
-						{
-							Title:  "Milestone v1.0",
-							Issues: []changelog.Issue{changelogIssue1},
-						},
-					},
 					MergeGroups: []changelog.MergeGroup{
 						{
-							Title:  "Milestone v1.0",
+							Title:  "Merged Changes",
 							Merges: []changelog.Merge{changelogMerge1},
 						},
+						{
+							Title:     "Dependency Updates",
+							Merges:    []changelog.Merge{changelogBotMerge},
+							Collapsed: true,
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "WithoutFutureTag_GroupingLabel",
+			name: "WithoutFutureTag_DirectCommits",
 			g: &Generator{
 				ui: ui.NewNop(),
 				remoteRepo: &MockRemoteRepo{
@@ -785,92 +1083,377 @@ func TestGenerator_resolveReleases(t *testing.T) {
 			},
 			ctx: context.Background(),
 			s: spec.Spec{
-				Issues: spec.Issues{
-					Grouping:  spec.GroupingLabel,
-					BugLabels: []string{"bug"},
-				},
 				Merges: spec.Merges{
-					Grouping:          spec.GroupingLabel,
-					EnhancementLabels: []string{"enhancement"},
-				},
-				Content: spec.Content{
-					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+					Grouping: spec.GroupingSimple,
 				},
 			},
 			sortedTags: remote.Tags{tag3},
 			baseRev:    "v0.1.2",
-			issueMap: issueMap{
-				"v0.1.3": remote.Issues{issue1},
-			},
 			mergeMap: mergeMap{
 				"v0.1.3": remote.Merges{merge1},
 			},
+			pushMap: pushMap{
+				"v0.1.3": remote.Commits{directCommit},
+			},
 			expectedReleases: []changelog.Release{
 				{
 					TagName:    "v0.1.3",
 					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
 					TagTime:    t3,
-					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.3",
 					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
-					IssueGroups: []changelog.IssueGroup{
+					MergeGroups: []changelog.MergeGroup{
 						{
-							Title:  "Fixed Bugs",
-							Issues: []changelog.Issue{changelogIssue1},
+							Title:  "Merged Changes",
+							Merges: []changelog.Merge{changelogMerge1},
 						},
 					},
-					MergeGroups: []changelog.MergeGroup{
+					Commits: []changelog.Commit{
 						{
-							Title:  "Enhancements",
-							Merges: []changelog.Merge{changelogMerge1},
+							Hash:  "20c5414eccaa147f2d6644de4ca36f35293fa43e",
+							Title: "Update the README",
+							URL:   "https://github.com/octocat/Hello-World/commit/20c5414eccaa147f2d6644de4ca36f35293fa43e",
+							Author: changelog.User{
+								Name:     "The Octodog",
+								Username: "octodog",
+								URL:      "https://github.com/octodog",
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "WithFutureTag_GroupingMilestone",
+			name: "WithoutFutureTag_AnnotatedTag",
 			g: &Generator{
 				ui: ui.NewNop(),
 				remoteRepo: &MockRemoteRepo{
 					CompareURLMocks: []CompareURLMock{
-						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.3...v0.1.4"},
 						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
 					},
 				},
 			},
 			ctx: context.Background(),
 			s: spec.Spec{
-				Issues: spec.Issues{
-					Grouping: spec.GroupingMilestone,
+				Tags: spec.Tags{
+					Date: spec.TagDateTag,
 				},
 				Merges: spec.Merges{
-					Grouping: spec.GroupingMilestone,
+					Grouping: spec.GroupingSimple,
 				},
 				Content: spec.Content{
-					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+					TagMessage: true,
 				},
 			},
-			sortedTags: remote.Tags{futureTag, tag3},
+			sortedTags: remote.Tags{annotatedTag},
 			baseRev:    "v0.1.2",
-			issueMap: issueMap{
-				"v0.1.4": remote.Issues{issue2},
-				"v0.1.3": remote.Issues{issue1},
-			},
 			mergeMap: mergeMap{
-				"v0.1.4": remote.Merges{merge2},
 				"v0.1.3": remote.Merges{merge1},
 			},
 			expectedReleases: []changelog.Release{
 				{
-					TagName:    "v0.1.4",
-					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.4",
-					TagTime:    now,
-					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.4",
-					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.3...v0.1.4",
-					IssueGroups: []changelog.IssueGroup{
+					TagName:    "v0.1.3",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
+					TagTime:    annotatedTag.TaggedAt,
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
+					TagMessage: "Release highlights",
+					MergeGroups: []changelog.MergeGroup{
 						{
-							Title:  "Closed Issues",
-							Issues: []changelog.Issue{changelogIssue2},
+							Title:  "Merged Changes",
+							Merges: []changelog.Merge{changelogMerge1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WithoutFutureTag_GroupingMilestone",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Grouping: spec.GroupingMilestone,
+				},
+				Merges: spec.Merges{
+					Grouping: spec.GroupingMilestone,
+				},
+				Content: spec.Content{
+					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+				},
+			},
+			sortedTags: remote.Tags{tag3},
+			baseRev:    "v0.1.2",
+			issueMap: issueMap{
+				"v0.1.3": remote.Issues{issue1},
+			},
+			mergeMap: mergeMap{
+				"v0.1.3": remote.Merges{merge1},
+			},
+			expectedReleases: []changelog.Release{
+				{
+					TagName:    "v0.1.3",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
+					TagTime:    t3,
+					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.3",
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
+					IssueGroups: []changelog.IssueGroup{
+						{
+							Title:  "Milestone v1.0",
+							Issues: []changelog.Issue{changelogIssue1},
+						},
+					},
+					MergeGroups: []changelog.MergeGroup{
+						{
+							Title:  "Milestone v1.0",
+							Merges: []changelog.Merge{changelogMerge1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WithoutFutureTag_GroupingChain",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Grouping:    spec.Grouping("milestone>scope"),
+					ScopePrefix: "area/",
+				},
+				Merges: spec.Merges{
+					Grouping:          spec.Grouping("milestone>label"),
+					EnhancementLabels: []string{"enhancement"},
+				},
+			},
+			sortedTags: remote.Tags{tag3},
+			baseRev:    "v0.1.2",
+			issueMap: issueMap{
+				"v0.1.3": remote.Issues{issue1},
+			},
+			mergeMap: mergeMap{
+				"v0.1.3": remote.Merges{merge1},
+			},
+			expectedReleases: []changelog.Release{
+				{
+					TagName:    "v0.1.3",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
+					TagTime:    t3,
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
+					IssueGroups: []changelog.IssueGroup{
+						{
+							Title:  "Milestone v1.0",
+							Issues: []changelog.Issue{changelogIssue1},
+						},
+					},
+					MergeGroups: []changelog.MergeGroup{
+						{
+							Title: "Milestone v1.0",
+							Subgroups: []changelog.MergeGroup{
+								{
+									Title:  "Enhancements",
+									Merges: []changelog.Merge{changelogMerge1},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WithoutFutureTag_LayoutUnified",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Grouping:          spec.GroupingLabel,
+					BugLabels:         []string{"bug"},
+					EnhancementLabels: []string{"enhancement"},
+				},
+				Merges: spec.Merges{
+					Bots:     spec.BotsCollapse,
+					Grouping: spec.GroupingSimple,
+				},
+				Changes: spec.Changes{
+					Layout: spec.LayoutUnified,
+				},
+			},
+			sortedTags: remote.Tags{tag3},
+			baseRev:    "v0.1.2",
+			issueMap: issueMap{
+				"v0.1.3": remote.Issues{issue1},
+			},
+			mergeMap: mergeMap{
+				"v0.1.3": remote.Merges{merge1, botMerge},
+			},
+			expectedReleases: []changelog.Release{
+				{
+					TagName:    "v0.1.3",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
+					TagTime:    t3,
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
+					ChangeGroups: []changelog.ChangeGroup{
+						{
+							Title: "Enhancements",
+							Changes: []changelog.Change{
+								{
+									Kind:     changelog.ChangeKindMerge,
+									Number:   changelogMerge1.Number,
+									Title:    changelogMerge1.Title,
+									URL:      changelogMerge1.URL,
+									OpenedBy: changelogMerge1.OpenedBy,
+									ClosedBy: changelogMerge1.MergedBy,
+								},
+							},
+						},
+						{
+							Title: "Fixed Bugs",
+							Changes: []changelog.Change{
+								{
+									Kind:     changelog.ChangeKindIssue,
+									Number:   changelogIssue1.Number,
+									Title:    changelogIssue1.Title,
+									URL:      changelogIssue1.URL,
+									OpenedBy: changelogIssue1.OpenedBy,
+									ClosedBy: changelogIssue1.ClosedBy,
+								},
+							},
+						},
+						{
+							Title: "Dependency Updates",
+							Changes: []changelog.Change{
+								{
+									Kind:     changelog.ChangeKindMerge,
+									Number:   changelogBotMerge.Number,
+									Title:    changelogBotMerge.Title,
+									URL:      changelogBotMerge.URL,
+									OpenedBy: changelogBotMerge.OpenedBy,
+									ClosedBy: changelogBotMerge.MergedBy,
+								},
+							},
+							Collapsed: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WithoutFutureTag_GroupingLabel",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Grouping:  spec.GroupingLabel,
+					BugLabels: []string{"bug"},
+				},
+				Merges: spec.Merges{
+					Grouping:          spec.GroupingLabel,
+					EnhancementLabels: []string{"enhancement"},
+				},
+				Content: spec.Content{
+					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+				},
+			},
+			sortedTags: remote.Tags{tag3},
+			baseRev:    "v0.1.2",
+			issueMap: issueMap{
+				"v0.1.3": remote.Issues{issue1},
+			},
+			mergeMap: mergeMap{
+				"v0.1.3": remote.Merges{merge1},
+			},
+			expectedReleases: []changelog.Release{
+				{
+					TagName:    "v0.1.3",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.3",
+					TagTime:    t3,
+					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.3",
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3",
+					IssueGroups: []changelog.IssueGroup{
+						{
+							Title:  "Fixed Bugs",
+							Issues: []changelog.Issue{changelogIssue1},
+						},
+					},
+					MergeGroups: []changelog.MergeGroup{
+						{
+							Title:  "Enhancements",
+							Merges: []changelog.Merge{changelogMerge1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WithFutureTag_GroupingMilestone",
+			g: &Generator{
+				ui: ui.NewNop(),
+				remoteRepo: &MockRemoteRepo{
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.3...v0.1.4"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Grouping: spec.GroupingMilestone,
+				},
+				Merges: spec.Merges{
+					Grouping: spec.GroupingMilestone,
+				},
+				Content: spec.Content{
+					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+				},
+			},
+			sortedTags: remote.Tags{futureTag, tag3},
+			baseRev:    "v0.1.2",
+			issueMap: issueMap{
+				"v0.1.4": remote.Issues{issue2},
+				"v0.1.3": remote.Issues{issue1},
+			},
+			mergeMap: mergeMap{
+				"v0.1.4": remote.Merges{merge2},
+				"v0.1.3": remote.Merges{merge1},
+			},
+			expectedReleases: []changelog.Release{
+				{
+					TagName:    "v0.1.4",
+					TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.4",
+					TagTime:    now,
+					ReleaseURL: "https://storage.artifactory.com/project/releases/v0.1.4",
+					CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.3...v0.1.4",
+					IssueGroups: []changelog.IssueGroup{
+						{
+							Title:  "Closed Issues",
+							Issues: []changelog.Issue{changelogIssue2},
 						},
 					},
 					MergeGroups: []changelog.MergeGroup{
@@ -981,14 +1564,174 @@ func TestGenerator_resolveReleases(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			releases := tc.g.resolveReleases(tc.ctx, tc.s, tc.sortedTags, tc.baseRev, tc.issueMap, tc.mergeMap)
+			releases := tc.g.resolveReleases(tc.ctx, tc.s, tc.sortedTags, tc.baseRev, tc.issueMap, tc.mergeMap, tc.pushMap)
 
 			assert.Equal(t, tc.expectedReleases, releases)
 		})
 	}
 }
 
+func TestGenerator_resolveReleases_BreakingLabels(t *testing.T) {
+	breakingIssue := issue1
+	breakingIssue.Labels = remote.Labels{"bug", "breaking"}
+
+	g := &Generator{
+		ui: ui.NewNop(),
+		remoteRepo: &MockRemoteRepo{
+			CompareURLMocks: []CompareURLMock{
+				{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+			},
+		},
+	}
+
+	s := spec.Spec{
+		Issues: spec.Issues{
+			Grouping:       spec.GroupingLabel,
+			BugLabels:      []string{"bug"},
+			BreakingLabels: []string{"breaking"},
+		},
+		Merges: spec.Merges{
+			Grouping:          spec.GroupingLabel,
+			EnhancementLabels: []string{"enhancement"},
+		},
+		Changes: spec.Changes{
+			Breaking: spec.Breaking{
+				Title: true,
+			},
+		},
+	}
+
+	im := issueMap{"v0.1.3": remote.Issues{breakingIssue, issue2}}
+	mm := mergeMap{"v0.1.3": remote.Merges{merge1}}
+
+	releases := g.resolveReleases(context.Background(), s, remote.Tags{tag3}, "v0.1.2", im, mm, nil)
+
+	assert.Len(t, releases, 1)
+	assert.Equal(t, []changelog.BreakingChange{
+		{Number: 1001, Title: "Found a bug", URL: "https://github.com/octocat/Hello-World/issues/1001"},
+	}, releases[0].BreakingChanges)
+
+	// The breaking-labeled issue is only rendered once in the breaking changes of the release
+	p := markdown.NewProcessor(ui.NewNop(), "", filepath.Join(t.TempDir(), "CHANGELOG.md"))
+	_, err := p.Parse(changelog.ParseOptions{})
+	assert.NoError(t, err)
+
+	content, err := p.Render(&changelog.Changelog{New: releases})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(content, "[#1001]"))
+	assert.Contains(t, content, "**:warning: Breaking Changes:**")
+	assert.NotContains(t, content, "**Breaking Changes:**")
+	assert.NotContains(t, content, "**Fixed Bugs:**")
+}
+
+func TestGenerator_resolveReleases_PendingPrerelease(t *testing.T) {
+	rc1 := remote.Tag{Name: "v0.2.0-rc.1", Time: t2, Commit: commit2}
+	rc2 := remote.Tag{Name: "v0.2.0-rc.2", Time: t3, Commit: commit3}
+
+	g := &Generator{
+		ui: ui.NewNop(),
+		remoteRepo: &MockRemoteRepo{
+			CompareURLMocks: []CompareURLMock{
+				{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.2.0-rc.2"},
+			},
+		},
+	}
+
+	s := spec.Spec{
+		Tags: spec.Tags{
+			Prerelease:       spec.PrereleaseRollup,
+			PrereleaseOrigin: true,
+		},
+		Issues: spec.Issues{
+			Grouping: spec.GroupingSimple,
+		},
+		Merges: spec.Merges{
+			Grouping: spec.GroupingSimple,
+		},
+	}
+
+	chlog := &changelog.Changelog{
+		Existing: []changelog.Release{
+			{TagName: "v0.1.1"},
+		},
+	}
+
+	// There is no v0.2.0 tag yet, so the changes of v0.2.0-rc.1 are rolled up into v0.2.0-rc.2
+	newTags, err := g.resolveTags(s.Tags, remote.Tags{rc2, rc1, tag1}, chlog)
+	assert.NoError(t, err)
+	assert.Equal(t, remote.Tags{rc2}, newTags)
+
+	im := issueMap{"v0.2.0-rc.1": remote.Issues{issue1}}
+	mm := mergeMap{"v0.2.0-rc.2": remote.Merges{merge1}}
+
+	releases := g.resolveReleases(context.Background(), s, newTags, "v0.1.1", im, mm, nil)
+
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "v0.2.0-rc.2", releases[0].TagName)
+	assert.Len(t, releases[0].IssueGroups, 1)
+	assert.Len(t, releases[0].IssueGroups[0].Issues, 1)
+	assert.Equal(t, 1001, releases[0].IssueGroups[0].Issues[0].Number)
+	assert.Equal(t, "v0.2.0-rc.1", releases[0].IssueGroups[0].Issues[0].Prerelease)
+	assert.Len(t, releases[0].MergeGroups, 1)
+	assert.Len(t, releases[0].MergeGroups[0].Merges, 1)
+	assert.Equal(t, 1003, releases[0].MergeGroups[0].Merges[0].Number)
+	assert.Empty(t, releases[0].MergeGroups[0].Merges[0].Prerelease)
+}
+
+func TestGenerator_resolveReleases_SummaryLabels(t *testing.T) {
+	summaryIssue := issue1
+	summaryIssue.Labels = remote.Labels{"bug", "summary"}
+	summaryIssue.Body = "This release fixes a bug."
+
+	g := &Generator{
+		ui: ui.NewNop(),
+		remoteRepo: &MockRemoteRepo{
+			CompareURLMocks: []CompareURLMock{
+				{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.3"},
+			},
+		},
+	}
+
+	s := spec.Spec{
+		Issues: spec.Issues{
+			Grouping:      spec.GroupingLabel,
+			BugLabels:     []string{"bug"},
+			SummaryLabels: []string{"summary"},
+		},
+		Merges: spec.Merges{
+			Grouping:          spec.GroupingLabel,
+			EnhancementLabels: []string{"enhancement"},
+		},
+		Content: spec.Content{
+			Summary: true,
+		},
+	}
+
+	im := issueMap{"v0.1.3": remote.Issues{summaryIssue}}
+	mm := mergeMap{"v0.1.3": remote.Merges{merge1}}
+
+	releases := g.resolveReleases(context.Background(), s, remote.Tags{tag3}, "v0.1.2", im, mm, nil)
+
+	// The summary-labeled issue is only rendered in the summary of the release
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "This release fixes a bug.", releases[0].Summary)
+	assert.Empty(t, releases[0].IssueGroups)
+	assert.Equal(t, []changelog.MergeGroup{
+		{
+			Title:  "Enhancements",
+			Merges: []changelog.Merge{changelogMerge1},
+		},
+	}, releases[0].MergeGroups)
+}
+
 func TestGenerator_Generate(t *testing.T) {
+	changelogFile := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	err := os.WriteFile(changelogFile, []byte("# Changelog\n\nchangelog\n"), 0644)
+	assert.NoError(t, err)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
 	tests := []struct {
 		name            string
 		g               *Generator
@@ -1188,13 +1931,221 @@ func TestGenerator_Generate(t *testing.T) {
 			expectedError: "error on fetching parent commits for branch",
 		},
 		{
-			name: "FetchParentCommitsFails_Tag",
+			name: "FetchParentCommitsFails_Tag",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutError: errors.New("error on fetching parent commits for tag")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on fetching parent commits for tag",
+		},
+		{
+			name: "FetchIssuesAndMergesFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{OutError: errors.New("error on fetching issues and merges")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on fetching issues and merges",
+		},
+		{
+			name: "RenderFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{}},
+					},
+					RenderMocks: []RenderMock{
+						{OutError: errors.New("error on rendering changelog")},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on rendering changelog",
+		},
+		{
+			name: "Success_ToTag",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{}},
+					},
+					RenderMocks: []RenderMock{
+						{OutContent: "changelog"},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx:             context.Background(),
+			s:               spec.Spec{},
+			expectedContent: "changelog",
+		},
+		{
+			name: "Success_FromAndToTags",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Existing: []changelog.Release{
+									{TagName: "v0.1.1"},
+								},
+							},
+						},
+					},
+					RenderMocks: []RenderMock{
+						{OutContent: "changelog"},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag2, tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+						{OutCommits: remote.Commits{commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2"},
+					},
+				},
+			},
+			ctx:             context.Background(),
+			s:               spec.Spec{},
+			expectedContent: "changelog",
+		},
+		{
+			name: "Success_FutureTag",
 			g: &Generator{
 				ui: ui.NewNop(),
 				processor: &MockChangelogProcessor{
 					ParseMocks: []ParseMock{
 						{OutChangelog: &changelog.Changelog{}},
 					},
+					RenderMocks: []RenderMock{
+						{OutContent: "changelog"},
+					},
 				},
 				remoteRepo: &MockRemoteRepo{
 					CheckPermissionsMocks: []CheckPermissionsMock{
@@ -1204,29 +2155,55 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutBranch: branch},
 					},
 					FetchTagsMocks: []FetchTagsMock{
-						{OutTags: remote.Tags{tag1}},
+						{OutTags: remote.Tags{}},
+					},
+					FutureTagMocks: []FutureTagMock{
+						{
+							OutTag: remote.Tag{
+								Name:   "v0.1.0",
+								Time:   time.Now(),
+								WebURL: "https://github.com/octocat/Hello-World/tree/v0.1.0",
+							},
+						},
 					},
 					FetchFirstCommitMocks: []FetchFirstCommitMock{
 						{OutCommit: commit1},
 					},
 					FetchParentCommitsMocks: []FetchParentCommitsMock{
 						{OutCommits: remote.Commits{commit3, commit2, commit1}},
-						{OutError: errors.New("error on fetching parent commits for tag")},
+						{OutCommits: remote.Commits{commit2, commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.0"},
 					},
 				},
 			},
-			ctx:           context.Background(),
-			s:             spec.Spec{},
-			expectedError: "error on fetching parent commits for tag",
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Future: "v0.1.0",
+				},
+			},
+			expectedContent: "changelog",
 		},
 		{
-			name: "FetchIssuesAndMergesFails",
+			name: "PostWriteHookFails",
 			g: &Generator{
-				ui: ui.NewNop(),
+				ui:    ui.NewNop(),
+				hooks: &hookRunner{ui: ui.NewNop()},
 				processor: &MockChangelogProcessor{
 					ParseMocks: []ParseMock{
 						{OutChangelog: &changelog.Changelog{}},
 					},
+					RenderMocks: []RenderMock{
+						{OutContent: "changelog"},
+					},
 				},
 				remoteRepo: &MockRemoteRepo{
 					CheckPermissionsMocks: []CheckPermissionsMock{
@@ -1236,7 +2213,16 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutBranch: branch},
 					},
 					FetchTagsMocks: []FetchTagsMock{
-						{OutTags: remote.Tags{tag1}},
+						{OutTags: remote.Tags{}},
+					},
+					FutureTagMocks: []FutureTagMock{
+						{
+							OutTag: remote.Tag{
+								Name:   "HEAD",
+								Time:   time.Now(),
+								WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
+							},
+						},
 					},
 					FetchFirstCommitMocks: []FetchFirstCommitMock{
 						{OutCommit: commit1},
@@ -1246,16 +2232,34 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutCommits: remote.Commits{commit2, commit1}},
 					},
 					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
-						{OutError: errors.New("error on fetching issues and merges")},
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...HEAD"},
 					},
 				},
 			},
-			ctx:           context.Background(),
-			s:             spec.Spec{},
-			expectedError: "error on fetching issues and merges",
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Unreleased: true,
+				},
+				Hooks: spec.Hooks{
+					PreGenerate: []spec.Hook{
+						{Command: "exit 0"},
+					},
+					PostWrite: []spec.Hook{
+						{Command: "echo prettier failed >&2; exit 3"},
+					},
+				},
+			},
+			expectedError: `post-write hook "echo prettier failed >&2; exit 3": exit status 3: prettier failed`,
 		},
 		{
-			name: "RenderFails",
+			name: "Success_Unreleased",
 			g: &Generator{
 				ui: ui.NewNop(),
 				processor: &MockChangelogProcessor{
@@ -1263,7 +2267,7 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutChangelog: &changelog.Changelog{}},
 					},
 					RenderMocks: []RenderMock{
-						{OutError: errors.New("error on rendering changelog")},
+						{OutContent: "changelog"},
 					},
 				},
 				remoteRepo: &MockRemoteRepo{
@@ -1274,7 +2278,16 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutBranch: branch},
 					},
 					FetchTagsMocks: []FetchTagsMock{
-						{OutTags: remote.Tags{tag1}},
+						{OutTags: remote.Tags{}},
+					},
+					FutureTagMocks: []FutureTagMock{
+						{
+							OutTag: remote.Tag{
+								Name:   "HEAD",
+								Time:   time.Now(),
+								WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
+							},
+						},
 					},
 					FetchFirstCommitMocks: []FetchFirstCommitMock{
 						{OutCommit: commit1},
@@ -1290,18 +2303,26 @@ func TestGenerator_Generate(t *testing.T) {
 						},
 					},
 					CompareURLMocks: []CompareURLMock{
-						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...HEAD"},
 					},
 				},
 			},
-			ctx:           context.Background(),
-			s:             spec.Spec{},
-			expectedError: "error on rendering changelog",
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Unreleased: true,
+				},
+			},
+			expectedContent: "changelog",
 		},
 		{
-			name: "Success_ToTag",
+			name: "Success_UnreleasedWithoutNotification",
 			g: &Generator{
 				ui: ui.NewNop(),
+				notifier: &notifier{
+					ui:     ui.NewNop(),
+					client: http.DefaultClient,
+				},
 				processor: &MockChangelogProcessor{
 					ParseMocks: []ParseMock{
 						{OutChangelog: &changelog.Changelog{}},
@@ -1318,7 +2339,16 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutBranch: branch},
 					},
 					FetchTagsMocks: []FetchTagsMock{
-						{OutTags: remote.Tags{tag1}},
+						{OutTags: remote.Tags{}},
+					},
+					FutureTagMocks: []FutureTagMock{
+						{
+							OutTag: remote.Tag{
+								Name:   "HEAD",
+								Time:   time.Now(),
+								WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
+							},
+						},
 					},
 					FetchFirstCommitMocks: []FetchFirstCommitMock{
 						{OutCommit: commit1},
@@ -1334,27 +2364,30 @@ func TestGenerator_Generate(t *testing.T) {
 						},
 					},
 					CompareURLMocks: []CompareURLMock{
-						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...HEAD"},
 					},
 				},
 			},
-			ctx:             context.Background(),
-			s:               spec.Spec{},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Unreleased: true,
+				},
+				// Notifying the sink fails if there is an attempt to send a notification
+				Sinks: []spec.Sink{
+					{Type: spec.SinkSlack, URL: "http://127.0.0.1:0/slack"},
+				},
+			},
 			expectedContent: "changelog",
 		},
 		{
-			name: "Success_FromAndToTags",
+			name: "PostRenderHookFails",
 			g: &Generator{
-				ui: ui.NewNop(),
+				ui:    ui.NewNop(),
+				hooks: &hookRunner{ui: ui.NewNop()},
 				processor: &MockChangelogProcessor{
 					ParseMocks: []ParseMock{
-						{
-							OutChangelog: &changelog.Changelog{
-								Existing: []changelog.Release{
-									{TagName: "v0.1.1"},
-								},
-							},
-						},
+						{OutChangelog: &changelog.Changelog{}},
 					},
 					RenderMocks: []RenderMock{
 						{OutContent: "changelog"},
@@ -1368,13 +2401,23 @@ func TestGenerator_Generate(t *testing.T) {
 						{OutBranch: branch},
 					},
 					FetchTagsMocks: []FetchTagsMock{
-						{OutTags: remote.Tags{tag2, tag1}},
+						{OutTags: remote.Tags{}},
+					},
+					FutureTagMocks: []FutureTagMock{
+						{
+							OutTag: remote.Tag{
+								Name:   "HEAD",
+								Time:   time.Now(),
+								WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
+							},
+						},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
 					},
-					FetchFirstCommitMocks: []FetchFirstCommitMock{},
 					FetchParentCommitsMocks: []FetchParentCommitsMock{
 						{OutCommits: remote.Commits{commit3, commit2, commit1}},
 						{OutCommits: remote.Commits{commit2, commit1}},
-						{OutCommits: remote.Commits{commit1}},
 					},
 					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
 						{
@@ -1383,19 +2426,28 @@ func TestGenerator_Generate(t *testing.T) {
 						},
 					},
 					CompareURLMocks: []CompareURLMock{
-						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
-						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...HEAD"},
 					},
 				},
 			},
-			ctx:             context.Background(),
-			s:               spec.Spec{},
-			expectedContent: "changelog",
+			ctx: canceledCtx,
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Unreleased: true,
+				},
+				Hooks: spec.Hooks{
+					PostRender: []spec.Hook{
+						{Command: "exit 0"},
+					},
+				},
+			},
+			expectedError: `post-render hook "exit 0": context canceled`,
 		},
 		{
-			name: "Success_FutureTag",
+			name: "Success_PostRenderHook",
 			g: &Generator{
-				ui: ui.NewNop(),
+				ui:    ui.NewNop(),
+				hooks: &hookRunner{ui: ui.NewNop()},
 				processor: &MockChangelogProcessor{
 					ParseMocks: []ParseMock{
 						{OutChangelog: &changelog.Changelog{}},
@@ -1417,9 +2469,9 @@ func TestGenerator_Generate(t *testing.T) {
 					FutureTagMocks: []FutureTagMock{
 						{
 							OutTag: remote.Tag{
-								Name:   "v0.1.0",
+								Name:   "HEAD",
 								Time:   time.Now(),
-								WebURL: "https://github.com/octocat/Hello-World/tree/v0.1.0",
+								WebURL: "https://github.com/octocat/Hello-World/tree/HEAD",
 							},
 						},
 					},
@@ -1437,17 +2489,25 @@ func TestGenerator_Generate(t *testing.T) {
 						},
 					},
 					CompareURLMocks: []CompareURLMock{
-						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.0"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...HEAD"},
 					},
 				},
 			},
 			ctx: context.Background(),
 			s: spec.Spec{
+				General: spec.General{
+					File: changelogFile,
+				},
 				Tags: spec.Tags{
-					Future: "v0.1.0",
+					Unreleased: true,
+				},
+				Hooks: spec.Hooks{
+					PostRender: []spec.Hook{
+						{Template: "{{ .Content }} :rocket:"},
+					},
 				},
 			},
-			expectedContent: "changelog",
+			expectedContent: "changelog :rocket:",
 		},
 	}
 
@@ -1465,3 +2525,119 @@ func TestGenerator_Generate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_Resolve(t *testing.T) {
+	tests := []struct {
+		name              string
+		g                 *Generator
+		ctx               context.Context
+		s                 spec.Spec
+		expectedChangelog *changelog.Changelog
+		expectedError     string
+	}{
+		{
+			name: "ParseFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutError: errors.New("error on parsing the changelog file")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on parsing the changelog file",
+		},
+		{
+			name: "NoNewTag",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{}},
+					},
+				},
+			},
+			ctx:               context.Background(),
+			s:                 spec.Spec{},
+			expectedChangelog: &changelog.Changelog{Title: "Changelog"},
+		},
+		{
+			name: "Success",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s:   spec.Spec{},
+			expectedChangelog: &changelog.Changelog{
+				Title: "Changelog",
+				New: []changelog.Release{
+					{
+						TagName:    "v0.1.1",
+						TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.1",
+						TagTime:    t1,
+						CompareURL: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chlog, err := tc.g.Resolve(tc.ctx, tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChangelog, chlog)
+			} else {
+				assert.Nil(t, chlog)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -1,13 +1,35 @@
 package generate
 
 import (
-	"github.com/gardenbed/changelog/internal/changelog"
-	"github.com/gardenbed/changelog/internal/remote"
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/internal/filter"
+	"github.com/gardenbed/changelog/internal/git"
+	"github.com/gardenbed/changelog/internal/jira"
+	"github.com/gardenbed/changelog/internal/semver"
+	"github.com/gardenbed/changelog/remote"
 	"github.com/gardenbed/changelog/spec"
 )
 
-// revisions refers to a branch name and list of tags sorted from the most recent to the least recent.
+// botGroupTitle is the title of the group for changes by bots.
+const botGroupTitle = "Dependency Updates"
+
+// releaseNotesRegex matches the fenced release-notes blocks in descriptions of changes.
+// These blocks are added to the summary of a release and they are different from the release-note blocks overriding titles of merges.
+var releaseNotesRegex = regexp.MustCompile("(?s)```release-notes[ \t]*\r?\n(.*?)\r?\n```")
+
+// breakingTitleRegex matches the titles of changes with a Conventional Commits breaking change marker (i.e. feat!: or feat(api)!:).
+var breakingTitleRegex = regexp.MustCompile(`^[A-Za-z]+(\([^)]*\))?!:`)
+
+// revisions refers to a commit along with a branch name and list of tags sorted from the most recent to the least recent.
 type revisions struct {
+	Commit remote.Commit
 	Branch string
 	Tags   []string
 }
@@ -24,6 +46,134 @@ type issueMap map[string]remote.Issues
 // It allows us to look up all merges for a tatg.
 type mergeMap map[string]remote.Merges
 
+// pushMap is a map of tag names to commits pushed directly to a branch.
+// It allows us to look up all direct commits for a tag.
+type pushMap map[string]remote.Commits
+
+// changeKey identifies an issue or a merge.
+// Issue and merge numbers are not unique together (i.e. GitLab numbers issues and merge requests separately).
+type changeKey struct {
+	Kind   changelog.ChangeKind
+	Number int
+}
+
+// originMap is a map of issues and merges to the pre-release tags that first shipped them.
+type originMap map[changeKey]string
+
+// annotateTags adds the metadata of local annotated tags to the tags that are not annotated by the remote repository.
+func annotateTags(tags remote.Tags, annotatedTags []git.AnnotatedTag) remote.Tags {
+	annotations := map[string]git.AnnotatedTag{}
+	for _, a := range annotatedTags {
+		annotations[a.Name] = a
+	}
+
+	result := make(remote.Tags, len(tags))
+	for i, t := range tags {
+		if a, ok := annotations[t.Name]; ok && !t.IsAnnotated() {
+			t.Message = strings.TrimSpace(a.Message)
+			t.Tagger = remote.User{
+				Name:  a.TaggerName,
+				Email: a.TaggerEmail,
+			}
+			t.TaggedAt = a.TaggedAt
+		}
+		result[i] = t
+	}
+
+	return result
+}
+
+// sortAndFilterTags sorts tags from the most recent to the least recent and removes the excluded tags.
+func sortAndFilterTags(s spec.Tags, tags remote.Tags) (remote.Tags, error) {
+	sortedTags := tags.Sort()
+	sortedTags = sortedTags.Exclude(s.Exclude...)
+
+	if s.ExcludeRegex != "" {
+		re, err := regexp.CompilePOSIX(s.ExcludeRegex)
+		if err != nil {
+			return nil, err
+		}
+		sortedTags = sortedTags.ExcludeRegex(re)
+	}
+
+	return sortedTags, nil
+}
+
+// resolveSince returns the time since when issues and merges should be fetched.
+// Issues and merges are partitioned by the commit times of tags, so the commit time of the last tag on changelog is used
+// even if the release time in the changelog is the date of an annotated tag.
+func resolveSince(existing []changelog.Release, sortedTags remote.Tags) time.Time {
+	if len(existing) == 0 {
+		return time.Time{}
+	}
+
+	if tag, ok := sortedTags.Find(existing[0].TagName); ok {
+		return tag.Time
+	}
+
+	return existing[0].TagTime
+}
+
+// Names of the stages of filtering issues and merges.
+const (
+	filterStageLabels       = "labels"
+	filterStageAuthors      = "authors"
+	filterStageExpressions  = "filter"
+	filterStageReleaseNotes = "release-notes"
+	filterStageDirectives   = "directives"
+)
+
+// filterStage is a stage of filtering issues and merges before they are partitioned by tags.
+type filterStage struct {
+	Name   string
+	Filter func(remote.Issues, remote.Merges) (remote.Issues, remote.Merges, error)
+}
+
+// filterStages returns the stages of filtering issues and merges in order.
+func filterStages(s spec.Spec) []filterStage {
+	stages := []filterStage{
+		{
+			Name: filterStageLabels,
+			Filter: func(issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+				issues, merges = filterByLabels(s, issues, merges)
+				return issues, merges, nil
+			},
+		},
+		{
+			Name: filterStageAuthors,
+			Filter: func(issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+				return filterByAuthors(s, issues, merges)
+			},
+		},
+		{
+			Name: filterStageExpressions,
+			Filter: func(issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+				return filterByExpressions(s, issues, merges)
+			},
+		},
+	}
+
+	if s.Merges.ReleaseNotes {
+		stages = append(stages, filterStage{
+			Name: filterStageReleaseNotes,
+			Filter: func(issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+				return issues, applyReleaseNotes(merges), nil
+			},
+		})
+	}
+
+	// Directives are applied after release notes, so title overrides take precedence
+	stages = append(stages, filterStage{
+		Name: filterStageDirectives,
+		Filter: func(issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+			issues, merges = applyDirectives(s.Changes.Directives, issues, merges)
+			return issues, merges, nil
+		},
+	})
+
+	return stages
+}
+
 func filterByLabels(s spec.Spec, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges) {
 	switch s.Issues.Selection {
 	case spec.SelectionNone:
@@ -96,6 +246,439 @@ func filterByLabels(s spec.Spec, issues remote.Issues, merges remote.Merges) (re
 	return issues, merges
 }
 
+// filterByAuthors filters issues and merges by their authors.
+func filterByAuthors(s spec.Spec, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+	var issuesRE, mergesRE *regexp.Regexp
+
+	if s.Issues.ExcludeAuthorsRegex != "" {
+		re, err := regexp.CompilePOSIX(s.Issues.ExcludeAuthorsRegex)
+		if err != nil {
+			return nil, nil, err
+		}
+		issuesRE = re
+	}
+
+	if s.Merges.ExcludeAuthorsRegex != "" {
+		re, err := regexp.CompilePOSIX(s.Merges.ExcludeAuthorsRegex)
+		if err != nil {
+			return nil, nil, err
+		}
+		mergesRE = re
+	}
+
+	issues, _ = issues.Select(func(i remote.Issue) bool {
+		return includeAuthor(i.Author, s.Issues.IncludeAuthors, s.Issues.ExcludeAuthors, issuesRE, s.Issues.Bots)
+	})
+
+	merges, _ = merges.Select(func(m remote.Merge) bool {
+		return includeAuthor(m.Author, s.Merges.IncludeAuthors, s.Merges.ExcludeAuthors, mergesRE, s.Merges.Bots)
+	})
+
+	return issues, merges, nil
+}
+
+// includeAuthor determines whether or not a change by an author should be included.
+func includeAuthor(author remote.User, include, exclude []string, excludeRE *regexp.Regexp, bots spec.Bots) bool {
+	if len(include) > 0 && !contains(include, author.Username) {
+		return false
+	}
+
+	if contains(exclude, author.Username) {
+		return false
+	}
+
+	if excludeRE != nil && excludeRE.MatchString(author.Username) {
+		return false
+	}
+
+	if bots == spec.BotsExclude && author.IsBot() {
+		return false
+	}
+
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByExpressions filters issues and merges by the filter expressions.
+func filterByExpressions(s spec.Spec, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+	if s.Issues.Filter != "" {
+		expr, err := filter.Parse(s.Issues.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		issues, _ = issues.Select(func(i remote.Issue) bool {
+			return expr.Match(i.Change)
+		})
+	}
+
+	if s.Merges.Filter != "" {
+		expr, err := filter.Parse(s.Merges.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		merges, _ = merges.Select(func(m remote.Merge) bool {
+			return expr.Match(m.Change)
+		})
+	}
+
+	return issues, merges, nil
+}
+
+// applyReleaseNotes replaces the titles of merges with their release notes.
+// Merges with NONE release notes are removed.
+func applyReleaseNotes(merges remote.Merges) remote.Merges {
+	applied := remote.Merges{}
+	for _, m := range merges {
+		if note, ok := m.ReleaseNote(); ok {
+			if strings.EqualFold(note, "NONE") {
+				continue
+			}
+			m.Title = note
+		}
+
+		applied = append(applied, m)
+	}
+
+	return applied
+}
+
+// directiveParser parses the changelog directives in titles and descriptions of changes.
+type directiveParser struct {
+	skipMarkers []string
+	lineRegex   *regexp.Regexp
+}
+
+// newDirectiveParser creates a new directive parser.
+// A directive line is a line starting with the directive keyword followed by a colon (i.e. changelog: skip).
+func newDirectiveParser(s spec.Directives) *directiveParser {
+	p := &directiveParser{}
+
+	for _, marker := range s.SkipMarkers {
+		if marker != "" {
+			p.skipMarkers = append(p.skipMarkers, strings.ToLower(marker))
+		}
+	}
+
+	if s.Keyword != "" {
+		p.lineRegex = regexp.MustCompile(`(?im)^[ \t]*` + regexp.QuoteMeta(s.Keyword) + `:[ \t]*(\S.*?)[ \t]*\r?$`)
+	}
+
+	return p
+}
+
+// Parse determines whether or not a change should be skipped and returns the title override of the change if any.
+func (p *directiveParser) Parse(title, body string) (bool, string) {
+	lowerTitle, lowerBody := strings.ToLower(title), strings.ToLower(body)
+	for _, marker := range p.skipMarkers {
+		if strings.Contains(lowerTitle, marker) || strings.Contains(lowerBody, marker) {
+			return true, ""
+		}
+	}
+
+	if p.lineRegex != nil {
+		if sm := p.lineRegex.FindStringSubmatch(body); len(sm) == 2 {
+			if strings.EqualFold(sm[1], "skip") {
+				return true, ""
+			}
+			return false, sm[1]
+		}
+	}
+
+	return false, ""
+}
+
+// applyDirectives removes the issues and merges skipped by directives and overrides their titles.
+func applyDirectives(s spec.Directives, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges) {
+	p := newDirectiveParser(s)
+
+	appliedIssues := remote.Issues{}
+	for _, i := range issues {
+		skip, title := p.Parse(i.Title, i.Body)
+		if skip {
+			continue
+		}
+		if title != "" {
+			i.Title = title
+		}
+		appliedIssues = append(appliedIssues, i)
+	}
+
+	appliedMerges := remote.Merges{}
+	for _, m := range merges {
+		skip, title := p.Parse(m.Title, m.Body)
+		if skip {
+			continue
+		}
+		if title != "" {
+			m.Title = title
+		}
+		appliedMerges = append(appliedMerges, m)
+	}
+
+	return appliedIssues, appliedMerges
+}
+
+// ticketEnricher enriches changes with the Jira tickets they reference.
+type ticketEnricher struct {
+	client   *jira.Client
+	keyRegex *regexp.Regexp
+	sources  []string
+	tickets  map[string]*remote.Ticket
+}
+
+// newTicketEnricher creates a new ticket enricher.
+func newTicketEnricher(s spec.Jira, client *jira.Client) (*ticketEnricher, error) {
+	re, err := regexp.Compile(s.KeyRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticketEnricher{
+		client:   client,
+		keyRegex: re,
+		sources:  s.Sources,
+		tickets:  map[string]*remote.Ticket{},
+	}, nil
+}
+
+// lookup retrieves the tickets for the keys found in the given sources.
+// Keys without a ticket (i.e. false positives of the key regex) are ignored.
+func (e *ticketEnricher) lookup(ctx context.Context, sources map[string]string) ([]remote.Ticket, error) {
+	var tickets []remote.Ticket
+	seen := map[string]bool{}
+
+	for _, source := range e.sources {
+		for _, key := range e.keyRegex.FindAllString(sources[source], -1) {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			t, ok := e.tickets[key]
+			if !ok {
+				issue, err := e.client.GetIssue(ctx, key)
+				if err != nil && !errors.Is(err, jira.ErrNotFound) {
+					return nil, err
+				}
+
+				if err == nil {
+					t = &remote.Ticket{
+						Key:         issue.Key,
+						Type:        issue.Type,
+						Summary:     issue.Summary,
+						FixVersions: issue.FixVersions,
+						WebURL:      issue.WebURL,
+					}
+				}
+
+				// Keys without a ticket are cached too
+				e.tickets[key] = t
+			}
+
+			if t != nil {
+				tickets = append(tickets, *t)
+			}
+		}
+	}
+
+	return tickets, nil
+}
+
+// enrich adds the tickets to a change.
+// The type of each ticket is added as a label (i.e. New Feature as new-feature),
+// and the first fix version is used as the milestone if the change does not have any.
+func (e *ticketEnricher) enrich(c *remote.Change, tickets []remote.Ticket) {
+	if len(tickets) == 0 {
+		return
+	}
+
+	c.Tickets = tickets
+	labels := append(remote.Labels{}, c.Labels...)
+
+	for _, t := range tickets {
+		if t.Type != "" {
+			label := strings.ToLower(strings.Join(strings.Fields(t.Type), "-"))
+			if !labels.Any(label) {
+				labels = append(labels, label)
+			}
+		}
+
+		if c.Milestone == "" && len(t.FixVersions) > 0 {
+			c.Milestone = t.FixVersions[0]
+		}
+	}
+
+	c.Labels = labels
+}
+
+// Enrich enriches issues and merges with the Jira tickets referenced in their titles, descriptions, and source branches.
+func (e *ticketEnricher) Enrich(ctx context.Context, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges, error) {
+	enrichedIssues := remote.Issues{}
+	for _, i := range issues {
+		tickets, err := e.lookup(ctx, map[string]string{
+			spec.JiraSourceTitle: i.Title,
+			spec.JiraSourceBody:  i.Body,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		e.enrich(&i.Change, tickets)
+		enrichedIssues = append(enrichedIssues, i)
+	}
+
+	enrichedMerges := remote.Merges{}
+	for _, m := range merges {
+		tickets, err := e.lookup(ctx, map[string]string{
+			spec.JiraSourceTitle:  m.Title,
+			spec.JiraSourceBody:   m.Body,
+			spec.JiraSourceBranch: m.SourceBranch(),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		e.enrich(&m.Change, tickets)
+		enrichedMerges = append(enrichedMerges, m)
+	}
+
+	return enrichedIssues, enrichedMerges, nil
+}
+
+// splitBotIssues separates the issues by bots if they should be grouped separately.
+func splitBotIssues(bots spec.Bots, issues remote.Issues) (remote.Issues, remote.Issues) {
+	if bots != spec.BotsGroup && bots != spec.BotsCollapse {
+		return issues, nil
+	}
+
+	botIssues, issues := issues.Select(func(i remote.Issue) bool {
+		return i.Author.IsBot()
+	})
+
+	return issues, botIssues
+}
+
+// splitBotMerges separates the merges by bots if they should be grouped separately.
+func splitBotMerges(bots spec.Bots, merges remote.Merges) (remote.Merges, remote.Merges) {
+	if bots != spec.BotsGroup && bots != spec.BotsCollapse {
+		return merges, nil
+	}
+
+	botMerges, merges := merges.Select(func(m remote.Merge) bool {
+		return m.Author.IsBot()
+	})
+
+	return merges, botMerges
+}
+
+// excludeLabeled removes the issues and merges with any of the given labels.
+func excludeLabeled(issueLabels, mergeLabels []string, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges) {
+	if len(issueLabels) > 0 {
+		_, issues = issues.Select(func(i remote.Issue) bool {
+			return i.Labels.Any(issueLabels...)
+		})
+	}
+
+	if len(mergeLabels) > 0 {
+		_, merges = merges.Select(func(m remote.Merge) bool {
+			return m.Labels.Any(mergeLabels...)
+		})
+	}
+
+	return issues, merges
+}
+
+// dedupChanges removes duplicate entries for issues and the merges closing them according to the dedup policy.
+func dedupChanges(dedup spec.Dedup, issues remote.Issues, merges remote.Merges) (remote.Issues, remote.Merges) {
+	if dedup == "" || dedup == spec.DedupNone {
+		return issues, merges
+	}
+
+	// Merges closing at least one of the issues
+	closing := map[int]bool{}
+
+	dedupedIssues := remote.Issues{}
+	for _, i := range issues {
+		closingMerges, _ := merges.Select(func(m remote.Merge) bool {
+			return m.Closes(i)
+		})
+
+		for _, m := range closingMerges {
+			closing[m.Number] = true
+		}
+
+		switch dedup {
+		case spec.DedupMerge:
+			if len(closingMerges) > 0 {
+				continue
+			}
+		case spec.DedupBoth:
+			if len(closingMerges) > 0 {
+				i.Merges = closingMerges
+			}
+		}
+
+		dedupedIssues = append(dedupedIssues, i)
+	}
+
+	dedupedMerges := merges
+	if dedup == spec.DedupIssue || dedup == spec.DedupBoth {
+		dedupedMerges, _ = merges.Select(func(m remote.Merge) bool {
+			return !closing[m.Number]
+		})
+	}
+
+	return dedupedIssues, dedupedMerges
+}
+
+// labelGroup is a label group with its label regex compiled.
+type labelGroup struct {
+	spec.LabelGroup
+	labelRE *regexp.Regexp
+}
+
+// compileLabelGroups compiles the label regexes of label groups, so they are compiled once and not for every change.
+// Label regexes are validated when the spec is loaded and an invalid regex does not match any label.
+func compileLabelGroups(groups []spec.LabelGroup) []labelGroup {
+	compiled := []labelGroup{}
+	for _, g := range groups {
+		lg := labelGroup{LabelGroup: g}
+		if g.LabelRegex != "" {
+			lg.labelRE, _ = regexp.CompilePOSIX(g.LabelRegex)
+		}
+		compiled = append(compiled, lg)
+	}
+
+	return compiled
+}
+
+// Match determines whether or not a list of labels belongs to the label group.
+func (g labelGroup) Match(labels remote.Labels) bool {
+	if labels.Any(g.Labels...) {
+		return true
+	}
+
+	if g.labelRE != nil {
+		for _, l := range labels {
+			if g.labelRE.MatchString(l) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // resolveIssueMap partitions a list of issues by tags.
 // It returns a map of tag names to issues.
 func resolveIssueMap(issues remote.Issues, sortedTags remote.Tags, futureTag remote.Tag) issueMap {
@@ -112,7 +695,7 @@ func resolveIssueMap(issues remote.Issues, sortedTags remote.Tags, futureTag rem
 			im[tag.Name] = append(im[tag.Name], i)
 		} else {
 			// The issue does not belong to any existing tag
-			// If there is a future tag, we should assign the issue to it
+			// If there is a future or an unreleased tag, we should assign the issue to it
 			if futureTag.Commit.IsZero() {
 				im[futureTag.Name] = append(im[futureTag.Name], i)
 			}
@@ -134,7 +717,7 @@ func resolveMergeMap(merges remote.Merges, cm commitMap, futureTag remote.Tag) m
 				mm[tagName] = append(mm[tagName], m)
 			} else {
 				// The commit does not belong to any existing tag
-				// If there is a future tag, we should assign the merge to it
+				// If there is a future or an unreleased tag, we should assign the merge to it
 				if futureTag.Commit.IsZero() {
 					tagName := futureTag.Name
 					mm[tagName] = append(mm[tagName], m)
@@ -147,12 +730,610 @@ func resolveMergeMap(merges remote.Merges, cm commitMap, futureTag remote.Tag) m
 	return mm
 }
 
+// commitKey identifies a commit by its message and author.
+// Rebasing a pull/merge request creates new commits on the branch with new hashes and commit times,
+// but with the same messages and authors as the commits of the pull/merge request.
+type commitKey struct {
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+}
+
+func newCommitKey(c remote.Commit) commitKey {
+	return commitKey{
+		Message:     c.Message,
+		AuthorName:  c.Author.Name,
+		AuthorEmail: c.Author.Email,
+	}
+}
+
+// resolvePushMap partitions the commits pushed directly to a branch without pull/merge requests by tags.
+// Direct commits are the commits on the first-parent history of the branch that are not the commits of merged pull/merge requests.
+// It returns a map of tag names to commits sorted from the most recent to the least recent.
+func resolvePushMap(s spec.Commits, branch remote.Branch, merges remote.Merges, cm commitMap, futureTag remote.Tag) pushMap {
+	pm := pushMap{}
+
+	if s.Selection != spec.SelectionAll {
+		return pm
+	}
+
+	// The commits of merges are recognized by their hashes, or by their messages and authors if they are rebased
+	mergeHashes := map[string]bool{}
+	mergeKeys := map[commitKey]bool{}
+	for _, m := range merges {
+		mergeHashes[m.Commit.Hash] = true
+		for _, c := range m.Commits {
+			mergeHashes[c.Hash] = true
+			mergeKeys[newCommitKey(c)] = true
+		}
+	}
+
+	for hash := branch.Commit.Hash; hash != ""; {
+		rev, ok := cm[hash]
+		if !ok {
+			break
+		}
+
+		c := rev.Commit
+
+		// The first parent is the previous commit on the same branch
+		hash = ""
+		if len(c.Parents) > 0 {
+			hash = c.Parents[0]
+		}
+
+		if mergeHashes[c.Hash] || mergeKeys[newCommitKey(c)] {
+			continue
+		}
+
+		if !includeCommit(s, c) {
+			continue
+		}
+
+		if len(rev.Tags) > 0 {
+			tagName := rev.Tags[len(rev.Tags)-1]
+			pm[tagName] = append(pm[tagName], c)
+		} else if futureTag.Commit.IsZero() {
+			// The commit does not belong to any existing tag
+			// If there is a future or an unreleased tag, we should assign the commit to it
+			pm[futureTag.Name] = append(pm[futureTag.Name], c)
+		}
+	}
+
+	return pm
+}
+
+// includeCommit determines whether or not a direct commit should be included in the changelog.
+func includeCommit(s spec.Commits, c remote.Commit) bool {
+	if s.ExcludeMerges && c.IsMerge() {
+		return false
+	}
+
+	if s.ExcludeBots && c.Author.IsBot() {
+		return false
+	}
+
+	message := strings.ToLower(c.Message)
+	for _, marker := range s.SkipMarkers {
+		if marker != "" && strings.Contains(message, strings.ToLower(marker)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rollupTags removes the pre-release tags that are rolled up into other tags from a list of tags.
+// Pre-release tags are rolled up into their final release tag (i.e. v2.0.0-rc.1 into v2.0.0).
+// Until the final release tag is created, they are rolled up into the latest pre-release tag (i.e. v2.0.0-rc.1 into v2.0.0-rc.2),
+// so their changes are not lost. names are all tag names for finding the final and latest pre-release tags.
+func rollupTags(tags remote.Tags, names []string) remote.Tags {
+	selected, _ := tags.Select(func(t remote.Tag) bool {
+		v, ok := semver.Parse(t.Name)
+		if !ok || !v.IsPrerelease() {
+			return true
+		}
+
+		for _, name := range names {
+			// A final release tag or a more recent pre-release tag of the same version
+			if u, ok := semver.Parse(name); ok && u.Core() == v.Core() && u.Compare(v) > 0 {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return selected
+}
+
+// prereleaseTags returns the pre-release tags rolled up into a tag among a list of tag names.
+// These are the pre-release tags of a final release tag (i.e. v2.0.0-rc.1 for v2.0.0)
+// or the previous pre-release tags of a pre-release tag (i.e. v2.0.0-rc.1 for v2.0.0-rc.2).
+// The pre-release tags are sorted from the most recent to the least recent.
+func prereleaseTags(tagName string, names []string) []string {
+	version, ok := semver.Parse(tagName)
+	if !ok {
+		return nil
+	}
+
+	versions := map[string]semver.Version{}
+	for _, name := range names {
+		if v, ok := semver.Parse(name); ok && v.IsPrerelease() && v.Core() == version.Core() && v.Compare(version) < 0 {
+			versions[name] = v
+		}
+	}
+
+	prereleases := make([]string, 0, len(versions))
+	for name := range versions {
+		prereleases = append(prereleases, name)
+	}
+
+	sort.Slice(prereleases, func(i, j int) bool {
+		return versions[prereleases[i]].Compare(versions[prereleases[j]]) > 0
+	})
+
+	return prereleases
+}
+
+// rollupPrereleases returns the issues and merges of a tag along with those of the pre-release tags rolled up into it (i.e. v2.0.0-rc.1 for v2.0.0).
+// It also returns a map of issues and merges to the pre-release tags that first shipped them.
+func rollupPrereleases(tagName string, im issueMap, mm mergeMap) (remote.Issues, remote.Merges, originMap) {
+	issues, merges := im[tagName], mm[tagName]
+	origins := originMap{}
+
+	names := []string{}
+	for name := range im {
+		names = append(names, name)
+	}
+
+	for name := range mm {
+		names = append(names, name)
+	}
+
+	prereleases := prereleaseTags(tagName, names)
+
+	for _, name := range prereleases {
+		for _, i := range im[name] {
+			issues = append(issues, i)
+			origins[changeKey{changelog.ChangeKindIssue, i.Number}] = name
+		}
+
+		for _, m := range mm[name] {
+			merges = append(merges, m)
+			origins[changeKey{changelog.ChangeKindMerge, m.Number}] = name
+		}
+	}
+
+	return issues, merges, origins
+}
+
+// rollupPrereleaseCommits returns the direct commits of a tag along with those of the pre-release tags rolled up into it (i.e. v2.0.0-rc.1 for v2.0.0).
+func rollupPrereleaseCommits(tagName string, pm pushMap) remote.Commits {
+	commits := pm[tagName]
+
+	names := []string{}
+	for name := range pm {
+		names = append(names, name)
+	}
+
+	for _, name := range prereleaseTags(tagName, names) {
+		commits = append(commits, pm[name]...)
+	}
+
+	return commits
+}
+
+// annotatePrereleases sets the pre-release tags that first shipped the issues and merges of a release.
+func annotatePrereleases(release *changelog.Release, origins originMap) {
+	var issueGroups func([]changelog.IssueGroup)
+	issueGroups = func(groups []changelog.IssueGroup) {
+		for i := range groups {
+			for j := range groups[i].Issues {
+				groups[i].Issues[j].Prerelease = origins[changeKey{changelog.ChangeKindIssue, groups[i].Issues[j].Number}]
+			}
+			issueGroups(groups[i].Subgroups)
+		}
+	}
+
+	var mergeGroups func([]changelog.MergeGroup)
+	mergeGroups = func(groups []changelog.MergeGroup) {
+		for i := range groups {
+			for j := range groups[i].Merges {
+				groups[i].Merges[j].Prerelease = origins[changeKey{changelog.ChangeKindMerge, groups[i].Merges[j].Number}]
+			}
+			mergeGroups(groups[i].Subgroups)
+		}
+	}
+
+	var changeGroups func([]changelog.ChangeGroup)
+	changeGroups = func(groups []changelog.ChangeGroup) {
+		for i := range groups {
+			for j := range groups[i].Changes {
+				c := groups[i].Changes[j]
+				groups[i].Changes[j].Prerelease = origins[changeKey{c.Kind, c.Number}]
+			}
+			changeGroups(groups[i].Subgroups)
+		}
+	}
+
+	issueGroups(release.IssueGroups)
+	mergeGroups(release.MergeGroups)
+	changeGroups(release.ChangeGroups)
+}
+
+// resolveSummary extracts the summary of a release from the descriptions of its changes.
+// The summary consists of the descriptions of summary-labeled changes and the release-notes blocks in descriptions of merges.
+// If a summary-labeled change has release-notes blocks, only the blocks are used.
+func resolveSummary(s spec.Spec, issues remote.Issues, merges remote.Merges) string {
+	paragraphs := []string{}
+
+	add := func(text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+
+	releaseNotes := func(body string) []string {
+		blocks := []string{}
+		for _, sm := range releaseNotesRegex.FindAllStringSubmatch(body, -1) {
+			blocks = append(blocks, sm[1])
+		}
+		return blocks
+	}
+
+	for _, i := range issues {
+		if len(s.Issues.SummaryLabels) > 0 && i.Labels.Any(s.Issues.SummaryLabels...) {
+			if blocks := releaseNotes(i.Body); len(blocks) > 0 {
+				add(strings.Join(blocks, "\n\n"))
+			} else {
+				add(i.Body)
+			}
+		}
+	}
+
+	for _, m := range merges {
+		blocks := releaseNotes(m.Body)
+		if len(blocks) > 0 {
+			add(strings.Join(blocks, "\n\n"))
+		} else if len(s.Merges.SummaryLabels) > 0 && m.Labels.Any(s.Merges.SummaryLabels...) {
+			add(m.Body)
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// compileBreakingKeywords compiles the regex for the notes following breaking change keywords.
+// It returns nil if there is no keyword.
+func compileBreakingKeywords(keywords []string) *regexp.Regexp {
+	if len(keywords) == 0 {
+		return nil
+	}
+
+	quoted := make([]string, len(keywords))
+	for i, k := range keywords {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+
+	// A note continues until the first blank line
+	return regexp.MustCompile(`(?m)^(?:` + strings.Join(quoted, "|") + `):[ \t]*((?s:.*?))(?:\n[ \t]*\n|\z)`)
+}
+
+// resolveBreakingChanges finds the changes with a breaking change in a release.
+// A change is breaking if it has a breaking label, or if it is detected by the enabled rules.
+// The notes following a breaking change keyword (matched by keywordRE) in the description or the commit message are extracted as migration notes.
+func resolveBreakingChanges(s spec.Spec, keywordRE *regexp.Regexp, issues remote.Issues, merges remote.Merges) []changelog.BreakingChange {
+	rules := s.Changes.Breaking
+
+	notes := func(text string) []string {
+		if keywordRE == nil {
+			return nil
+		}
+
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+		notes := []string{}
+		for _, sm := range keywordRE.FindAllStringSubmatch(text, -1) {
+			if note := strings.TrimSpace(sm[1]); note != "" {
+				notes = append(notes, note)
+			}
+		}
+		return notes
+	}
+
+	detect := func(c remote.Change, commit remote.Commit, labels []string) (bool, string) {
+		breaking := len(labels) > 0 && c.Labels.Any(labels...)
+		if rules.Title && breakingTitleRegex.MatchString(c.Title) {
+			breaking = true
+		}
+
+		all := []string{}
+		if rules.Body {
+			all = append(all, notes(c.Body)...)
+		}
+		if rules.Commit {
+			all = append(all, notes(commit.Message)...)
+		}
+
+		return breaking || len(all) > 0, strings.Join(all, "\n\n")
+	}
+
+	changes := []changelog.BreakingChange{}
+
+	for _, i := range issues {
+		if ok, notes := detect(i.Change, i.Commit, s.Issues.BreakingLabels); ok {
+			changes = append(changes, changelog.BreakingChange{
+				Number: i.Number,
+				Title:  i.Title,
+				URL:    i.WebURL,
+				Notes:  notes,
+			})
+		}
+	}
+
+	for _, m := range merges {
+		if ok, notes := detect(m.Change, m.Commit, s.Merges.BreakingLabels); ok {
+			changes = append(changes, changelog.BreakingChange{
+				Number: m.Number,
+				Title:  m.Title,
+				URL:    m.WebURL,
+				Notes:  notes,
+			})
+		}
+	}
+
+	return changes
+}
+
+// resolveContributors aggregates the contributors of a release.
+// Contributors are the authors, closers, and mergers of issues and merges as well as the co-authors of merge commits.
+func resolveContributors(issues remote.Issues, merges remote.Merges) []changelog.Contributor {
+	users := map[string]remote.User{}
+	names := map[string]bool{}
+	emails := map[string]bool{}
+
+	add := func(u remote.User) {
+		key := u.Username
+		if key == "" {
+			// Co-authors do not have a username
+			if names[u.Name] || emails[u.Email] {
+				return
+			}
+			key = u.Name
+		}
+
+		if _, ok := users[key]; key != "" && !ok {
+			users[key] = u
+			if u.Name != "" {
+				names[u.Name] = true
+			}
+			if u.Email != "" {
+				emails[u.Email] = true
+			}
+		}
+	}
+
+	for _, m := range merges {
+		add(m.Author)
+		add(m.Merger)
+	}
+
+	for _, i := range issues {
+		add(i.Author)
+		add(i.Closer)
+		for _, m := range i.Merges {
+			add(m.Author)
+			add(m.Merger)
+		}
+	}
+
+	// Co-authors are resolved last, so they will not duplicate the users already found
+	for _, m := range merges {
+		for _, u := range m.Commit.CoAuthors() {
+			add(u)
+		}
+	}
+
+	keys := []string{}
+	for key := range users {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.ToLower(keys[i]) < strings.ToLower(keys[j])
+	})
+
+	contributors := []changelog.Contributor{}
+	for _, key := range keys {
+		u := users[key]
+		contributors = append(contributors, changelog.Contributor{
+			User: changelog.User{
+				Name:     u.Name,
+				Username: u.Username,
+				URL:      u.WebURL,
+			},
+		})
+	}
+
+	return contributors
+}
+
+// markFirstTimeContributors marks the contributors of new releases who have not contributed to any earlier release.
+// Both existing and new releases are expected to be sorted from the most recent to the least recent.
+// If existing releases do not have any contributor, previous contributors are unknown and no one will be marked.
+func markFirstTimeContributors(existing, new []changelog.Release) {
+	key := func(c changelog.Contributor) string {
+		if c.Username != "" {
+			return c.Username
+		}
+		return c.Name
+	}
+
+	known := map[string]bool{}
+	complete := len(existing) == 0
+
+	for _, r := range existing {
+		for _, c := range r.Contributors {
+			known[key(c)] = true
+			complete = true
+		}
+	}
+
+	if !complete {
+		return
+	}
+
+	for i := len(new) - 1; i >= 0; i-- {
+		for j, c := range new[i].Contributors {
+			new[i].Contributors[j].FirstTime = !known[key(c)]
+		}
+
+		for _, c := range new[i].Contributors {
+			known[key(c)] = true
+		}
+	}
+}
+
+// medianDuration returns the median of a list of durations or zero if the list is empty.
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// countIssueGroup counts the issues in a group and all its subgroups.
+func countIssueGroup(g changelog.IssueGroup) int {
+	n := len(g.Issues)
+	for _, sg := range g.Subgroups {
+		n += countIssueGroup(sg)
+	}
+
+	return n
+}
+
+// countMergeGroup counts the merges in a group and all its subgroups.
+func countMergeGroup(g changelog.MergeGroup) int {
+	n := len(g.Merges)
+	for _, sg := range g.Subgroups {
+		n += countMergeGroup(sg)
+	}
+
+	return n
+}
+
+// countChangeGroup counts the issues and merges in a group and all its subgroups.
+func countChangeGroup(g changelog.ChangeGroup) (int, int) {
+	var issues, merges int
+	for _, c := range g.Changes {
+		if c.Kind == changelog.ChangeKindIssue {
+			issues++
+		} else {
+			merges++
+		}
+	}
+
+	for _, sg := range g.Subgroups {
+		i, m := countChangeGroup(sg)
+		issues, merges = issues+i, merges+m
+	}
+
+	return issues, merges
+}
+
+// resolveStats computes the metrics of a release from its top-level groups and all of its issues and merges.
+// Lead times and times to close are only known for the changes with an opening time.
+func resolveStats(release changelog.Release, issues remote.Issues, merges remote.Merges) *changelog.Stats {
+	stats := &changelog.Stats{
+		Issues:       len(issues),
+		Merges:       len(merges),
+		Groups:       []changelog.GroupStats{},
+		Contributors: len(resolveContributors(issues, merges)),
+	}
+
+	for _, g := range release.IssueGroups {
+		stats.Groups = append(stats.Groups, changelog.GroupStats{Title: g.Title, Issues: countIssueGroup(g)})
+	}
+
+	for _, g := range release.MergeGroups {
+		stats.Groups = append(stats.Groups, changelog.GroupStats{Title: g.Title, Merges: countMergeGroup(g)})
+	}
+
+	for _, g := range release.ChangeGroups {
+		i, m := countChangeGroup(g)
+		stats.Groups = append(stats.Groups, changelog.GroupStats{Title: g.Title, Issues: i, Merges: m})
+	}
+
+	timesToClose := []time.Duration{}
+	for _, i := range issues {
+		if !i.CreatedAt.IsZero() && i.Time.After(i.CreatedAt) {
+			timesToClose = append(timesToClose, i.Time.Sub(i.CreatedAt))
+		}
+	}
+
+	leadTimes := []time.Duration{}
+	for _, m := range merges {
+		if !m.CreatedAt.IsZero() && m.Time.After(m.CreatedAt) {
+			leadTimes = append(leadTimes, m.Time.Sub(m.CreatedAt))
+		}
+	}
+
+	stats.TimeToClose = medianDuration(timesToClose)
+	stats.LeadTime = medianDuration(leadTimes)
+
+	return stats
+}
+
+// resolvePreviousReleases sets the previous release and the number of days since then for the stats of new releases.
+// Both existing and new releases are expected to be sorted from the most recent to the least recent.
+func resolvePreviousReleases(existing, new []changelog.Release) {
+	for i := range new {
+		if new[i].Stats == nil {
+			continue
+		}
+
+		var prev changelog.Release
+		if j := i + 1; j < len(new) {
+			prev = new[j]
+		} else if len(existing) > 0 {
+			prev = existing[0]
+		} else {
+			continue
+		}
+
+		new[i].Stats.Previous = prev.TagName
+		if !prev.TagTime.IsZero() && new[i].TagTime.After(prev.TagTime) {
+			new[i].Stats.Days = int(new[i].TagTime.Sub(prev.TagTime) / (24 * time.Hour))
+		}
+	}
+}
+
 func toIssueGroup(title string, issues remote.Issues) changelog.IssueGroup {
 	issueGroup := changelog.IssueGroup{
 		Title: title,
 	}
 
 	for _, i := range issues {
+		var merges []changelog.Reference
+		for _, m := range i.Merges {
+			merges = append(merges, changelog.Reference{
+				Number: m.Number,
+				URL:    m.WebURL,
+			})
+		}
+
 		issueGroup.Issues = append(issueGroup.Issues, changelog.Issue{
 			Number: i.Number,
 			Title:  i.Title,
@@ -167,6 +1348,8 @@ func toIssueGroup(title string, issues remote.Issues) changelog.IssueGroup {
 				Username: i.Closer.Username,
 				URL:      i.Closer.WebURL,
 			},
+			Merges:  merges,
+			Tickets: toTickets(i.Tickets),
 		})
 	}
 
@@ -193,8 +1376,91 @@ func toMergeGroup(title string, merges remote.Merges) changelog.MergeGroup {
 				Username: m.Merger.Username,
 				URL:      m.Merger.WebURL,
 			},
+			Tickets: toTickets(m.Tickets),
 		})
 	}
 
 	return mergeGroup
 }
+
+func toChangeGroup(title string, issues remote.Issues, merges remote.Merges) changelog.ChangeGroup {
+	changeGroup := changelog.ChangeGroup{
+		Title: title,
+	}
+
+	for _, i := range toIssueGroup(title, issues).Issues {
+		changeGroup.Changes = append(changeGroup.Changes, changelog.Change{
+			Kind:     changelog.ChangeKindIssue,
+			Number:   i.Number,
+			Title:    i.Title,
+			URL:      i.URL,
+			OpenedBy: i.OpenedBy,
+			ClosedBy: i.ClosedBy,
+			Merges:   i.Merges,
+			Tickets:  i.Tickets,
+		})
+	}
+
+	for _, m := range toMergeGroup(title, merges).Merges {
+		changeGroup.Changes = append(changeGroup.Changes, changelog.Change{
+			Kind:     changelog.ChangeKindMerge,
+			Number:   m.Number,
+			Title:    m.Title,
+			URL:      m.URL,
+			OpenedBy: m.OpenedBy,
+			ClosedBy: m.MergedBy,
+			Tickets:  m.Tickets,
+		})
+	}
+
+	return changeGroup
+}
+
+// toTickets converts a list of remote tickets to a list of changelog tickets.
+func toTickets(tickets []remote.Ticket) []changelog.Ticket {
+	var result []changelog.Ticket
+	for _, t := range tickets {
+		result = append(result, changelog.Ticket{
+			Key: t.Key,
+			URL: t.WebURL,
+		})
+	}
+
+	return result
+}
+
+// toCommits converts a list of remote commits to a list of changelog commits.
+func toCommits(commits remote.Commits) []changelog.Commit {
+	result := []changelog.Commit{}
+	for _, c := range commits {
+		result = append(result, changelog.Commit{
+			Hash:  c.Hash,
+			Title: c.Title(),
+			URL:   c.WebURL,
+			Author: changelog.User{
+				Name:     c.Author.Name,
+				Username: c.Author.Username,
+				URL:      c.Author.WebURL,
+			},
+		})
+	}
+
+	return result
+}
+
+// union returns a sorted list of unique strings from two lists.
+func union(a, b []string) []string {
+	mp := map[string]bool{}
+	for _, s := range append(a, b...) {
+		mp[s] = true
+	}
+
+	list := []string{}
+	for s := range mp {
+		list = append(list, s)
+	}
+
+	sort.Strings(list)
+
+	return list
+}
@@ -1,16 +1,177 @@
 package generate
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
-	"github.com/gardenbed/changelog/internal/changelog"
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/internal/git"
+	"github.com/gardenbed/changelog/internal/jira"
+	"github.com/gardenbed/changelog/remote"
 	"github.com/gardenbed/changelog/spec"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestAnnotateTags(t *testing.T) {
+	annotatedTag2 := tag2
+	annotatedTag2.Message = "Remote message"
+	annotatedTag2.TaggedAt = t2
+
+	annotatedTags := []git.AnnotatedTag{
+		{
+			Name:        "v0.1.1",
+			Message:     "Local message\n",
+			TaggerName:  "The Octocat",
+			TaggerEmail: "octocat@github.com",
+			TaggedAt:    t1,
+		},
+		{
+			Name:     "v0.1.2",
+			Message:  "Another local message\n",
+			TaggedAt: t2,
+		},
+	}
+
+	expectedTag1 := tag1
+	expectedTag1.Message = "Local message"
+	expectedTag1.Tagger = remote.User{Name: "The Octocat", Email: "octocat@github.com"}
+	expectedTag1.TaggedAt = t1
+
+	tags := annotateTags(remote.Tags{annotatedTag2, tag1, tag3}, annotatedTags)
+
+	assert.Equal(t, remote.Tags{annotatedTag2, expectedTag1, tag3}, tags)
+}
+
+func TestSortAndFilterTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             spec.Tags
+		tags          remote.Tags
+		expectedTags  remote.Tags
+		expectedError string
+	}{
+		{
+			name: "InvalidExcludeRegex",
+			s: spec.Tags{
+				ExcludeRegex: "[",
+			},
+			tags:          remote.Tags{tag1, tag2, tag3},
+			expectedError: "error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name:         "Sort",
+			s:            spec.Tags{},
+			tags:         remote.Tags{tag1, tag3, tag2},
+			expectedTags: remote.Tags{tag3, tag2, tag1},
+		},
+		{
+			name: "Exclude",
+			s: spec.Tags{
+				Exclude:      []string{"v0.1.3"},
+				ExcludeRegex: `v0\.1\.1`,
+			},
+			tags:         remote.Tags{tag1, tag2, tag3},
+			expectedTags: remote.Tags{tag2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := sortAndFilterTags(tc.s, tc.tags)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, tags)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTags, tags)
+			}
+		})
+	}
+}
+
+func TestResolveSince(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []changelog.Release
+		sortedTags    remote.Tags
+		expectedSince time.Time
+	}{
+		{
+			name:          "NoRelease",
+			existing:      nil,
+			sortedTags:    remote.Tags{tag2, tag1},
+			expectedSince: time.Time{},
+		},
+		{
+			name: "TagNotFound",
+			existing: []changelog.Release{
+				{TagName: "v0.1.0", TagTime: t1},
+			},
+			sortedTags:    remote.Tags{tag2, tag1},
+			expectedSince: t1,
+		},
+		{
+			name: "TagDate",
+			existing: []changelog.Release{
+				{TagName: "v0.1.2", TagTime: t3},
+				{TagName: "v0.1.1", TagTime: t1},
+			},
+			sortedTags:    remote.Tags{tag2, tag1},
+			expectedSince: t2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSince, resolveSince(tc.existing, tc.sortedTags))
+		})
+	}
+}
+
+func TestFilterStages(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             spec.Spec
+		expectedNames []string
+	}{
+		{
+			name:          "Default",
+			s:             spec.Spec{},
+			expectedNames: []string{"labels", "authors", "filter", "directives"},
+		},
+		{
+			name: "WithReleaseNotes",
+			s: spec.Spec{
+				Merges: spec.Merges{
+					ReleaseNotes: true,
+				},
+			},
+			expectedNames: []string{"labels", "authors", "filter", "release-notes", "directives"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{}
+			for _, stage := range filterStages(tc.s) {
+				names = append(names, stage.Name)
+
+				issues, merges, err := stage.Filter(remote.Issues{}, remote.Merges{})
+				assert.NoError(t, err)
+				assert.Empty(t, issues)
+				assert.Empty(t, merges)
+			}
+
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
 func TestFilterByLabels(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -115,87 +276,1544 @@ func TestFilterByLabels(t *testing.T) {
 	}
 }
 
-func TestResolveIssueMap(t *testing.T) {
-	futureTag := remote.Tag{
-		Name: "v0.1.4",
-		Time: time.Now(),
+func TestFilterByAuthors(t *testing.T) {
+	bot := remote.User{
+		Username: "dependabot[bot]",
+		WebURL:   "https://github.com/apps/dependabot",
 	}
 
+	merge3 := remote.Merge{
+		Change: remote.Change{
+			Number: 1005,
+			Title:  "Bump a dependency",
+			Author: bot,
+			WebURL: "https://github.com/octocat/Hello-World/pull/1005",
+		},
+		Merger: user1,
+	}
+
+	issue3 := issue2
+	issue3.Author = user2
+
 	tests := []struct {
-		name             string
-		issues           remote.Issues
-		sortedTags       remote.Tags
-		futureTag        remote.Tag
-		expectedIssueMap issueMap
+		name           string
+		s              spec.Spec
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedError  string
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
 	}{
 		{
-			name:       "OK",
-			issues:     remote.Issues{issue1, issue2},
-			sortedTags: remote.Tags{tag3, tag2, tag1},
-			futureTag:  futureTag,
-			expectedIssueMap: issueMap{
-				"v0.1.4": remote.Issues{issue2},
-				"v0.1.3": remote.Issues{issue1},
+			name: "InvalidIssuesRegex",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					ExcludeAuthorsRegex: "[",
+				},
+			},
+			expectedError: "error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "InvalidMergesRegex",
+			s: spec.Spec{
+				Merges: spec.Merges{
+					ExcludeAuthorsRegex: "[",
+				},
+			},
+			expectedError: "error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name:           "NoFilter",
+			s:              spec.Spec{},
+			issues:         remote.Issues{issue1, issue3},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1, issue3},
+			expectedMerges: remote.Merges{merge1, merge3},
+		},
+		{
+			name: "IncludeAuthors",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					IncludeAuthors: []string{"octodog"},
+				},
+				Merges: spec.Merges{
+					IncludeAuthors: []string{"octocat"},
+				},
+			},
+			issues:         remote.Issues{issue1, issue3},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue3},
+			expectedMerges: remote.Merges{merge1},
+		},
+		{
+			name: "ExcludeAuthors",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					ExcludeAuthors: []string{"octodog"},
+				},
+				Merges: spec.Merges{
+					ExcludeAuthorsRegex: `^dependabot`,
+				},
+			},
+			issues:         remote.Issues{issue1, issue3},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1},
+			expectedMerges: remote.Merges{merge1},
+		},
+		{
+			name: "ExcludeBots",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Bots: spec.BotsExclude,
+				},
+				Merges: spec.Merges{
+					Bots: spec.BotsExclude,
+				},
 			},
+			issues:         remote.Issues{issue1, issue3},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1, issue3},
+			expectedMerges: remote.Merges{merge1},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			issueMap := resolveIssueMap(tc.issues, tc.sortedTags, tc.futureTag)
+			issues, merges, err := filterByAuthors(tc.s, tc.issues, tc.merges)
 
-			assert.Equal(t, tc.expectedIssueMap, issueMap)
+			if tc.expectedError != "" {
+				assert.Nil(t, issues)
+				assert.Nil(t, merges)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIssues, issues)
+				assert.Equal(t, tc.expectedMerges, merges)
+			}
 		})
 	}
 }
 
-func TestResolveMergeMap(t *testing.T) {
-	futureTag := remote.Tag{
-		Name: "v0.1.4",
-	}
-
-	cm := commitMap{
-		"20c5414eccaa147f2d6644de4ca36f35293fa43e": &revisions{
-			Branch: "main",
+func TestFilterByExpressions(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              spec.Spec
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedError  string
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+	}{
+		{
+			name: "InvalidIssuesFilter",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Filter: "label:",
+				},
+			},
+			expectedError: `invalid filter expression: expected value after "label:" at position 6`,
 		},
-		"c414d1004154c6c324bd78c69d10ee101e676059": &revisions{
-			Branch: "main",
-			Tags:   []string{"v0.1.3"},
+		{
+			name: "InvalidMergesFilter",
+			s: spec.Spec{
+				Merges: spec.Merges{
+					Filter: "label:",
+				},
+			},
+			expectedError: `invalid filter expression: expected value after "label:" at position 6`,
 		},
-		"0251a422d2038967eeaaaa5c8aa76c7067fdef05": &revisions{
-			Branch: "main",
-			Tags:   []string{"v0.1.3", "v0.1.2"},
+		{
+			name:           "NoFilter",
+			s:              spec.Spec{},
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge2},
+			expectedIssues: remote.Issues{issue1, issue2},
+			expectedMerges: remote.Merges{merge1, merge2},
 		},
-		"25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378": &revisions{
-			Branch: "main",
-			Tags:   []string{"v0.1.3", "v0.1.2", "v0.1.1"},
+		{
+			name: "OK",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					Filter: `label:bug && milestone=="v1.0"`,
+				},
+				Merges: spec.Merges{
+					Filter: `!label:enhancement && title~"^Refactor"`,
+				},
+			},
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge2},
+			expectedIssues: remote.Issues{issue1},
+			expectedMerges: remote.Merges{merge2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, merges, err := filterByExpressions(tc.s, tc.issues, tc.merges)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, issues)
+				assert.Nil(t, merges)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIssues, issues)
+				assert.Equal(t, tc.expectedMerges, merges)
+			}
+		})
+	}
+}
+
+func TestApplyReleaseNotes(t *testing.T) {
+	merge3 := merge1
+	merge3.Body = "Added a feature.\n\n```release-note\nAdded the -verbose flag.\n```\n"
+
+	merge3WithNote := merge3
+	merge3WithNote.Title = "Added the -verbose flag."
+
+	merge4 := merge2
+	merge4.Body = "```release-note\nnone\n```"
+
+	tests := []struct {
+		name           string
+		merges         remote.Merges
+		expectedMerges remote.Merges
+	}{
+		{
+			name:           "NoReleaseNote",
+			merges:         remote.Merges{merge1, merge2},
+			expectedMerges: remote.Merges{merge1, merge2},
+		},
+		{
+			name:           "OK",
+			merges:         remote.Merges{merge1, merge3, merge4},
+			expectedMerges: remote.Merges{merge1, merge3WithNote},
 		},
 	}
 
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merges := applyReleaseNotes(tc.merges)
+
+			assert.Equal(t, tc.expectedMerges, merges)
+		})
+	}
+}
+
+func TestDirectiveParser_Parse(t *testing.T) {
 	tests := []struct {
-		name             string
-		merges           remote.Merges
-		commitMap        commitMap
-		futureTag        remote.Tag
-		expectedMergeMap mergeMap
+		name          string
+		s             spec.Directives
+		title         string
+		body          string
+		expectedSkip  bool
+		expectedTitle string
 	}{
 		{
-			name:      "OK",
-			merges:    remote.Merges{merge1, merge2},
-			commitMap: cm,
-			futureTag: futureTag,
-			expectedMergeMap: mergeMap{
-				"v0.1.4": remote.Merges{merge2},
-				"v0.1.3": remote.Merges{merge1},
-			},
+			name:          "NoDirective",
+			s:             spec.Directives{SkipMarkers: []string{"[skip changelog]"}, Keyword: "changelog"},
+			title:         "Add a feature",
+			body:          "This adds a new feature.",
+			expectedSkip:  false,
+			expectedTitle: "",
+		},
+		{
+			name:          "SkipMarkerInTitle",
+			s:             spec.Directives{SkipMarkers: []string{"[skip changelog]"}, Keyword: "changelog"},
+			title:         "Fix CI [Skip Changelog]",
+			body:          "",
+			expectedSkip:  true,
+			expectedTitle: "",
+		},
+		{
+			name:          "SkipMarkerInBody",
+			s:             spec.Directives{SkipMarkers: []string{"no-changelog"}, Keyword: "changelog"},
+			title:         "Fix CI",
+			body:          "Internal change.\n\nno-changelog",
+			expectedSkip:  true,
+			expectedTitle: "",
+		},
+		{
+			name:          "SkipLine",
+			s:             spec.Directives{Keyword: "changelog"},
+			title:         "Fix CI",
+			body:          "Internal change.\r\n\r\nChangelog: skip\r\n",
+			expectedSkip:  true,
+			expectedTitle: "",
+		},
+		{
+			name:          "TitleLine",
+			s:             spec.Directives{Keyword: "changelog"},
+			title:         "feat(cli): add -verbose",
+			body:          "Some details.\n\n  changelog: Added the `-verbose` flag  \n",
+			expectedSkip:  false,
+			expectedTitle: "Added the `-verbose` flag",
+		},
+		{
+			name:          "EmptyLine",
+			s:             spec.Directives{Keyword: "changelog"},
+			title:         "Add a feature",
+			body:          "changelog:\nMore details.",
+			expectedSkip:  false,
+			expectedTitle: "",
+		},
+		{
+			name:          "Disabled",
+			s:             spec.Directives{},
+			title:         "Fix CI [skip changelog]",
+			body:          "changelog: skip",
+			expectedSkip:  false,
+			expectedTitle: "",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			mergeMap := resolveMergeMap(tc.merges, tc.commitMap, tc.futureTag)
+			skip, title := newDirectiveParser(tc.s).Parse(tc.title, tc.body)
 
-			assert.Equal(t, tc.expectedMergeMap, mergeMap)
+			assert.Equal(t, tc.expectedSkip, skip)
+			assert.Equal(t, tc.expectedTitle, title)
+		})
+	}
+}
+
+func TestApplyDirectives(t *testing.T) {
+	skippedIssue := issue2
+	skippedIssue.Title = "Flaky test [skip changelog]"
+
+	overriddenIssue := issue1
+	overriddenIssue.Body = "changelog: Fixed a crash on startup"
+
+	expectedIssue := overriddenIssue
+	expectedIssue.Title = "Fixed a crash on startup"
+
+	skippedMerge := merge2
+	skippedMerge.Body = "changelog: skip"
+
+	// An ordinary line from a pull request template
+	templateMerge := merge1
+	templateMerge.Body = "## Description\n\nChangelog: N/A\n"
+
+	tests := []struct {
+		name           string
+		s              spec.Directives
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+	}{
+		{
+			name:           "Disabled",
+			s:              spec.Directives{},
+			issues:         remote.Issues{overriddenIssue, skippedIssue},
+			merges:         remote.Merges{templateMerge, skippedMerge},
+			expectedIssues: remote.Issues{overriddenIssue, skippedIssue},
+			expectedMerges: remote.Merges{templateMerge, skippedMerge},
+		},
+		{
+			name: "Enabled",
+			s: spec.Directives{
+				SkipMarkers: []string{"[skip changelog]"},
+				Keyword:     "changelog",
+			},
+			issues:         remote.Issues{overriddenIssue, skippedIssue},
+			merges:         remote.Merges{merge1, skippedMerge},
+			expectedIssues: remote.Issues{expectedIssue},
+			expectedMerges: remote.Merges{merge1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, merges := applyDirectives(tc.s, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedIssues, issues)
+			assert.Equal(t, tc.expectedMerges, merges)
+		})
+	}
+}
+
+func TestTicketEnricher_Enrich(t *testing.T) {
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-1":
+			_, _ = w.Write([]byte(`{ "key": "PROJ-1", "fields": { "summary": "Login fails", "issuetype": { "name": "Bug" }, "fixVersions": [ { "name": "1.2.0" } ] } }`))
+		case "/rest/api/2/issue/PROJ-2":
+			_, _ = w.Write([]byte(`{ "key": "PROJ-2", "fields": { "summary": "Support SSO", "issuetype": { "name": "New Feature" } } }`))
+		case "/rest/api/2/issue/PROJ-500":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ticket1 := remote.Ticket{
+		Key:         "PROJ-1",
+		Type:        "Bug",
+		Summary:     "Login fails",
+		FixVersions: []string{"1.2.0"},
+		WebURL:      ts.URL + "/browse/PROJ-1",
+	}
+
+	ticket2 := remote.Ticket{
+		Key:         "PROJ-2",
+		Type:        "New Feature",
+		Summary:     "Support SSO",
+		FixVersions: []string{},
+		WebURL:      ts.URL + "/browse/PROJ-2",
+	}
+
+	tests := []struct {
+		name           string
+		s              spec.Jira
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+		expectedError  string
+	}{
+		{
+			name: "JiraError",
+			s: spec.Jira{
+				KeyRegex: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+				Sources:  []string{"title"},
+			},
+			issues: remote.Issues{
+				{Change: remote.Change{Number: 1001, Title: "PROJ-500 Fix login"}},
+			},
+			expectedError: "/rest/api/2/issue/PROJ-500?fields=issuetype,summary,fixVersions 500",
+		},
+		{
+			name: "Success",
+			s: spec.Jira{
+				KeyRegex: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+				Sources:  []string{"title", "branch"},
+			},
+			issues: remote.Issues{
+				{Change: remote.Change{Number: 1001, Title: "PROJ-1 Login fails", Labels: remote.Labels{"bug"}}},
+				{Change: remote.Change{Number: 1002, Title: "Flaky test", Body: "See PROJ-2"}},
+			},
+			merges: remote.Merges{
+				{
+					Change: remote.Change{Number: 1003, Title: "Fix login (PROJ-1, UTF-8)", Milestone: "1.1.0"},
+				},
+				{
+					Change: remote.Change{Number: 1004, Title: "Add SSO"},
+					Commit: remote.Commit{Message: "Merge pull request #1004 from octodog/PROJ-2-sso"},
+				},
+			},
+			expectedIssues: remote.Issues{
+				{Change: remote.Change{Number: 1001, Title: "PROJ-1 Login fails", Labels: remote.Labels{"bug"}, Milestone: "1.2.0", Tickets: []remote.Ticket{ticket1}}},
+				{Change: remote.Change{Number: 1002, Title: "Flaky test", Body: "See PROJ-2"}},
+			},
+			expectedMerges: remote.Merges{
+				{
+					Change: remote.Change{Number: 1003, Title: "Fix login (PROJ-1, UTF-8)", Labels: remote.Labels{"bug"}, Milestone: "1.1.0", Tickets: []remote.Ticket{ticket1}},
+				},
+				{
+					Change: remote.Change{Number: 1004, Title: "Add SSO", Labels: remote.Labels{"new-feature"}, Tickets: []remote.Ticket{ticket2}},
+					Commit: remote.Commit{Message: "Merge pull request #1004 from octodog/PROJ-2-sso"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := newTicketEnricher(tc.s, jira.NewClient(ts.URL, "", "jira-token", ts.Client()))
+			assert.NoError(t, err)
+
+			issues, merges, err := e.Enrich(context.Background(), tc.issues, tc.merges)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, issues)
+				assert.Nil(t, merges)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIssues, issues)
+				assert.Equal(t, tc.expectedMerges, merges)
+			}
+		})
+	}
+
+	// Each ticket is only retrieved once
+	assert.Equal(t, 1, requests["/rest/api/2/issue/PROJ-1"])
+	assert.Equal(t, 1, requests["/rest/api/2/issue/UTF-8"])
+}
+
+func TestExcludeLabeled(t *testing.T) {
+	tests := []struct {
+		name           string
+		issueLabels    []string
+		mergeLabels    []string
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+	}{
+		{
+			name:           "NoLabel",
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge2},
+			expectedIssues: remote.Issues{issue1, issue2},
+			expectedMerges: remote.Merges{merge1, merge2},
+		},
+		{
+			name:           "OK",
+			issueLabels:    []string{"bug"},
+			mergeLabels:    []string{"enhancement"},
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge2},
+			expectedIssues: remote.Issues{issue2},
+			expectedMerges: remote.Merges{merge2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, merges := excludeLabeled(tc.issueLabels, tc.mergeLabels, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedIssues, issues)
+			assert.Equal(t, tc.expectedMerges, merges)
+		})
+	}
+}
+
+func TestDedupChanges(t *testing.T) {
+	merge3 := remote.Merge{
+		Change: remote.Change{
+			Number: 1005,
+			Title:  "Fixed the bug",
+			Body:   "Fixes #1001",
+			WebURL: "https://github.com/octocat/Hello-World/pull/1005",
+		},
+	}
+
+	issue1WithMerges := issue1
+	issue1WithMerges.Merges = remote.Merges{merge3}
+
+	tests := []struct {
+		name           string
+		dedup          spec.Dedup
+		issues         remote.Issues
+		merges         remote.Merges
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+	}{
+		{
+			name:           "None",
+			dedup:          spec.DedupNone,
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1, issue2},
+			expectedMerges: remote.Merges{merge1, merge3},
+		},
+		{
+			name:           "Issue",
+			dedup:          spec.DedupIssue,
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1, issue2},
+			expectedMerges: remote.Merges{merge1},
+		},
+		{
+			name:           "Merge",
+			dedup:          spec.DedupMerge,
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue2},
+			expectedMerges: remote.Merges{merge1, merge3},
+		},
+		{
+			name:           "Both",
+			dedup:          spec.DedupBoth,
+			issues:         remote.Issues{issue1, issue2},
+			merges:         remote.Merges{merge1, merge3},
+			expectedIssues: remote.Issues{issue1WithMerges, issue2},
+			expectedMerges: remote.Merges{merge1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, merges := dedupChanges(tc.dedup, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedIssues, issues)
+			assert.Equal(t, tc.expectedMerges, merges)
+		})
+	}
+}
+
+func TestLabelGroup_Match(t *testing.T) {
+	tests := []struct {
+		name          string
+		group         spec.LabelGroup
+		labels        remote.Labels
+		expectedMatch bool
+	}{
+		{
+			name:          "NoLabel",
+			group:         spec.LabelGroup{Title: "Fixed Bugs", Labels: []string{"bug"}},
+			labels:        remote.Labels{},
+			expectedMatch: false,
+		},
+		{
+			name:          "LabelMatch",
+			group:         spec.LabelGroup{Title: "Fixed Bugs", Labels: []string{"bug", "defect"}},
+			labels:        remote.Labels{"defect"},
+			expectedMatch: true,
+		},
+		{
+			name:          "InvalidLabelRegex",
+			group:         spec.LabelGroup{Title: "Performance", LabelRegex: "["},
+			labels:        remote.Labels{"performance"},
+			expectedMatch: false,
+		},
+		{
+			name:          "LabelRegexMatch",
+			group:         spec.LabelGroup{Title: "Performance", LabelRegex: "^perf"},
+			labels:        remote.Labels{"enhancement", "performance"},
+			expectedMatch: true,
+		},
+		{
+			name:          "LabelRegexNoMatch",
+			group:         spec.LabelGroup{Title: "Performance", LabelRegex: "^perf"},
+			labels:        remote.Labels{"enhancement"},
+			expectedMatch: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			groups := compileLabelGroups([]spec.LabelGroup{tc.group})
+			assert.Equal(t, tc.expectedMatch, groups[0].Match(tc.labels))
+		})
+	}
+}
+
+func TestResolveIssueMap(t *testing.T) {
+	futureTag := remote.Tag{
+		Name: "v0.1.4",
+		Time: time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		issues           remote.Issues
+		sortedTags       remote.Tags
+		futureTag        remote.Tag
+		expectedIssueMap issueMap
+	}{
+		{
+			name:       "OK",
+			issues:     remote.Issues{issue1, issue2},
+			sortedTags: remote.Tags{tag3, tag2, tag1},
+			futureTag:  futureTag,
+			expectedIssueMap: issueMap{
+				"v0.1.4": remote.Issues{issue2},
+				"v0.1.3": remote.Issues{issue1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issueMap := resolveIssueMap(tc.issues, tc.sortedTags, tc.futureTag)
+
+			assert.Equal(t, tc.expectedIssueMap, issueMap)
+		})
+	}
+}
+
+func TestResolveMergeMap(t *testing.T) {
+	futureTag := remote.Tag{
+		Name: "v0.1.4",
+	}
+
+	cm := commitMap{
+		"20c5414eccaa147f2d6644de4ca36f35293fa43e": &revisions{
+			Branch: "main",
+		},
+		"c414d1004154c6c324bd78c69d10ee101e676059": &revisions{
+			Branch: "main",
+			Tags:   []string{"v0.1.3"},
+		},
+		"0251a422d2038967eeaaaa5c8aa76c7067fdef05": &revisions{
+			Branch: "main",
+			Tags:   []string{"v0.1.3", "v0.1.2"},
+		},
+		"25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378": &revisions{
+			Branch: "main",
+			Tags:   []string{"v0.1.3", "v0.1.2", "v0.1.1"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		merges           remote.Merges
+		commitMap        commitMap
+		futureTag        remote.Tag
+		expectedMergeMap mergeMap
+	}{
+		{
+			name:      "OK",
+			merges:    remote.Merges{merge1, merge2},
+			commitMap: cm,
+			futureTag: futureTag,
+			expectedMergeMap: mergeMap{
+				"v0.1.4": remote.Merges{merge2},
+				"v0.1.3": remote.Merges{merge1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mergeMap := resolveMergeMap(tc.merges, tc.commitMap, tc.futureTag)
+
+			assert.Equal(t, tc.expectedMergeMap, mergeMap)
+		})
+	}
+}
+
+func TestResolvePushMap(t *testing.T) {
+	futureTag := remote.Tag{
+		Name: "v0.1.4",
+	}
+
+	bot := remote.User{
+		Username: "dependabot[bot]",
+	}
+
+	c1 := remote.Commit{Hash: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378", Message: "Initial commit"}
+	c2 := remote.Commit{Hash: "0251a422d2038967eeaaaa5c8aa76c7067fdef05", Message: "Fix a typo [skip changelog]", Parents: []string{c1.Hash}}
+	c3 := remote.Commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059", Message: "Update the README", Parents: []string{c2.Hash}}
+	c4 := remote.Commit{Hash: "20c5414eccaa147f2d6644de4ca36f35293fa43e", Message: "Merge a pull request", Parents: []string{c3.Hash, "b3c1f1d"}}
+	c5 := remote.Commit{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Merge branch 'release'", Parents: []string{c4.Hash, "a1b2c3d"}}
+	c6 := remote.Commit{Hash: "c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c", Message: "Bump a dependency", Author: bot, Parents: []string{c5.Hash}}
+	c7 := remote.Commit{Hash: "a46edc8e32f15ff8a5e0b1f3c3e0a52e4e4c9a9b", Message: "Add a CONTRIBUTING file", Parents: []string{c6.Hash}}
+
+	m := remote.Merge{
+		Change: remote.Change{Number: 1002},
+		Commit: c4,
+	}
+
+	cm := commitMap{
+		c7.Hash: &revisions{Commit: c7, Branch: "main"},
+		c6.Hash: &revisions{Commit: c6, Branch: "main"},
+		c5.Hash: &revisions{Commit: c5, Branch: "main"},
+		c4.Hash: &revisions{Commit: c4, Branch: "main", Tags: []string{"v0.1.3"}},
+		c3.Hash: &revisions{Commit: c3, Branch: "main", Tags: []string{"v0.1.3"}},
+		c2.Hash: &revisions{Commit: c2, Branch: "main", Tags: []string{"v0.1.3", "v0.1.2"}},
+		c1.Hash: &revisions{Commit: c1, Branch: "main", Tags: []string{"v0.1.3", "v0.1.2", "v0.1.1"}},
+	}
+
+	branch := remote.Branch{
+		Name:   "main",
+		Commit: c7,
+	}
+
+	tests := []struct {
+		name            string
+		s               spec.Commits
+		futureTag       remote.Tag
+		expectedPushMap pushMap
+	}{
+		{
+			name: "SelectionNone",
+			s: spec.Commits{
+				Selection: spec.SelectionNone,
+			},
+			futureTag:       futureTag,
+			expectedPushMap: pushMap{},
+		},
+		{
+			name: "SelectionAll",
+			s: spec.Commits{
+				Selection: spec.SelectionAll,
+			},
+			futureTag: futureTag,
+			expectedPushMap: pushMap{
+				"v0.1.4": remote.Commits{c7, c6, c5},
+				"v0.1.3": remote.Commits{c3},
+				"v0.1.2": remote.Commits{c2},
+				"v0.1.1": remote.Commits{c1},
+			},
+		},
+		{
+			name: "WithFilters",
+			s: spec.Commits{
+				Selection:     spec.SelectionAll,
+				ExcludeMerges: true,
+				ExcludeBots:   true,
+				SkipMarkers:   []string{"[SKIP CHANGELOG]"},
+			},
+			futureTag: futureTag,
+			expectedPushMap: pushMap{
+				"v0.1.4": remote.Commits{c7},
+				"v0.1.3": remote.Commits{c3},
+				"v0.1.1": remote.Commits{c1},
+			},
+		},
+		{
+			name: "WithoutFutureTag",
+			s: spec.Commits{
+				Selection:     spec.SelectionAll,
+				ExcludeMerges: true,
+				ExcludeBots:   true,
+			},
+			futureTag: tag3,
+			expectedPushMap: pushMap{
+				"v0.1.3": remote.Commits{c3},
+				"v0.1.2": remote.Commits{c2},
+				"v0.1.1": remote.Commits{c1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pushMap := resolvePushMap(tc.s, branch, remote.Merges{m}, cm, tc.futureTag)
+
+			assert.Equal(t, tc.expectedPushMap, pushMap)
+		})
+	}
+}
+
+func TestResolvePushMap_Merges(t *testing.T) {
+	futureTag := remote.Tag{
+		Name: "v0.1.4",
+	}
+
+	author1 := remote.User{Name: "The Octocat", Email: "octocat@github.com"}
+	author2 := remote.User{Name: "The Octodog", Email: "octodog@github.com"}
+
+	// The commits of a pull request before being rebased on the branch
+	p1 := remote.Commit{Hash: "9a56ae8bfd8ff5d4a4c8d9e4d8a3bd2d1c6b8c53", Message: "Add a flag", Author: author2, Time: t1}
+	p2 := remote.Commit{Hash: "b2f1c0d5c8e1e3f2a4d6c8b0a2e4f6a8c0e2d4f6", Message: "Add tests", Author: author2, Time: t1}
+	p3 := remote.Commit{Hash: "e4d6f8a0c2e4b6d8f0a2c4e6b8d0f2a4c6e8b0d2", Message: "Update the docs", Author: author2, Time: t1}
+
+	tests := []struct {
+		name         string
+		commits      remote.Commits // From the most recent to the least recent
+		merge        remote.Merge
+		expectedPush remote.Commits
+	}{
+		{
+			name: "RebaseMerge_DifferentCommitTimes",
+			commits: remote.Commits{
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t4},
+				{Hash: "20c5414eccaa147f2d6644de4ca36f35293fa43e", Message: "Update the docs", Author: author2, Time: t3},
+				{Hash: "c414d1004154c6c324bd78c69d10ee101e676059", Message: "Add tests", Author: author2, Time: t2},
+				{Hash: "0251a422d2038967eeaaaa5c8aa76c7067fdef05", Message: "Add a flag", Author: author2, Time: t2},
+			},
+			merge: remote.Merge{
+				Change:  remote.Change{Number: 1002},
+				Commit:  remote.Commit{Hash: "20c5414eccaa147f2d6644de4ca36f35293fa43e"},
+				Commits: remote.Commits{p1, p2, p3},
+			},
+			expectedPush: remote.Commits{
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t4},
+			},
+		},
+		{
+			name: "SquashMerge_DirectCommitWithSameCommitTime",
+			commits: remote.Commits{
+				{Hash: "0251a422d2038967eeaaaa5c8aa76c7067fdef05", Message: "Add a flag (#1002)", Author: author2, Time: t2},
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t2},
+			},
+			merge: remote.Merge{
+				Change:  remote.Change{Number: 1002},
+				Commit:  remote.Commit{Hash: "0251a422d2038967eeaaaa5c8aa76c7067fdef05"},
+				Commits: remote.Commits{p1, p2, p3},
+			},
+			expectedPush: remote.Commits{
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t2},
+			},
+		},
+		{
+			name: "RebaseMerge_DirectCommitWithSameCommitTime",
+			commits: remote.Commits{
+				{Hash: "c414d1004154c6c324bd78c69d10ee101e676059", Message: "Add tests", Author: author2, Time: t2},
+				{Hash: "0251a422d2038967eeaaaa5c8aa76c7067fdef05", Message: "Add a flag", Author: author2, Time: t2},
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t2},
+			},
+			merge: remote.Merge{
+				Change:  remote.Change{Number: 1002},
+				Commit:  remote.Commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"},
+				Commits: remote.Commits{p1, p2},
+			},
+			expectedPush: remote.Commits{
+				{Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e", Message: "Update the README", Author: author1, Time: t2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The first commit is tagged and the rest are on top of it
+			initial := remote.Commit{Hash: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378", Message: "Initial commit", Author: author1, Time: t1}
+			cm := commitMap{
+				initial.Hash: &revisions{Commit: initial, Branch: "main", Tags: []string{"v0.1.1"}},
+			}
+
+			parent := initial.Hash
+			for i := len(tc.commits) - 1; i >= 0; i-- {
+				tc.commits[i].Parents = []string{parent}
+				cm[tc.commits[i].Hash] = &revisions{Commit: tc.commits[i], Branch: "main"}
+				parent = tc.commits[i].Hash
+			}
+
+			for i := range tc.expectedPush {
+				tc.expectedPush[i] = cm[tc.expectedPush[i].Hash].Commit
+			}
+
+			branch := remote.Branch{
+				Name:   "main",
+				Commit: tc.commits[0],
+			}
+
+			s := spec.Commits{
+				Selection: spec.SelectionAll,
+			}
+
+			pm := resolvePushMap(s, branch, remote.Merges{tc.merge}, cm, futureTag)
+
+			assert.Equal(t, pushMap{
+				"v0.1.4": tc.expectedPush,
+				"v0.1.1": remote.Commits{initial},
+			}, pm)
+		})
+	}
+}
+
+func TestRollupTags(t *testing.T) {
+	tag := func(name string) remote.Tag {
+		return remote.Tag{Name: name}
+	}
+
+	tests := []struct {
+		name         string
+		tags         remote.Tags
+		names        []string
+		expectedTags remote.Tags
+	}{
+		{
+			name:         "ReleaseTag",
+			tags:         remote.Tags{tag("v0.2.0"), tag("v0.2.0-rc.2"), tag("v0.2.0-rc.1"), tag("release-0.1.0")},
+			names:        []string{"v0.2.0", "v0.2.0-rc.2", "v0.2.0-rc.1", "release-0.1.0"},
+			expectedTags: remote.Tags{tag("v0.2.0"), tag("release-0.1.0")},
+		},
+		{
+			name:         "NoReleaseTag",
+			tags:         remote.Tags{tag("v0.2.0-rc.2"), tag("v0.2.0-rc.1"), tag("v0.1.0")},
+			names:        []string{"v0.2.0-rc.2", "v0.2.0-rc.1", "v0.1.0"},
+			expectedTags: remote.Tags{tag("v0.2.0-rc.2"), tag("v0.1.0")},
+		},
+		{
+			name:         "ReleaseTagNotNew",
+			tags:         remote.Tags{tag("v0.2.0-rc.1")},
+			names:        []string{"v0.2.0", "v0.2.0-rc.1"},
+			expectedTags: remote.Tags{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tags := rollupTags(tc.tags, tc.names)
+
+			assert.Equal(t, tc.expectedTags, tags)
+		})
+	}
+}
+
+func TestRollupPrereleases(t *testing.T) {
+	im := issueMap{
+		"v0.2.0":        remote.Issues{issue1},
+		"v0.2.0-rc.1":   remote.Issues{issue2},
+		"v0.1.0":        remote.Issues{},
+		"v0.1.0-rc.1":   remote.Issues{},
+		"release-0.2.0": remote.Issues{},
+	}
+
+	mm := mergeMap{
+		"v0.2.0-beta.1": remote.Merges{merge2},
+		"v0.2.0-rc.2":   remote.Merges{merge1},
+	}
+
+	tests := []struct {
+		name            string
+		tagName         string
+		expectedIssues  remote.Issues
+		expectedMerges  remote.Merges
+		expectedOrigins originMap
+	}{
+		{
+			name:            "NotSemVer",
+			tagName:         "release-0.2.0",
+			expectedIssues:  remote.Issues{},
+			expectedMerges:  nil,
+			expectedOrigins: originMap{},
+		},
+		{
+			name:           "Prerelease",
+			tagName:        "v0.2.0-rc.1",
+			expectedIssues: remote.Issues{issue2},
+			expectedMerges: remote.Merges{merge2},
+			expectedOrigins: originMap{
+				{changelog.ChangeKindMerge, 1004}: "v0.2.0-beta.1",
+			},
+		},
+		{
+			name:           "Release",
+			tagName:        "v0.2.0",
+			expectedIssues: remote.Issues{issue1, issue2},
+			expectedMerges: remote.Merges{merge1, merge2},
+			expectedOrigins: originMap{
+				{changelog.ChangeKindIssue, 1002}: "v0.2.0-rc.1",
+				{changelog.ChangeKindMerge, 1003}: "v0.2.0-rc.2",
+				{changelog.ChangeKindMerge, 1004}: "v0.2.0-beta.1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, merges, origins := rollupPrereleases(tc.tagName, im, mm)
+
+			assert.Equal(t, tc.expectedIssues, issues)
+			assert.Equal(t, tc.expectedMerges, merges)
+			assert.Equal(t, tc.expectedOrigins, origins)
+		})
+	}
+}
+
+func TestRollupPrereleaseCommits(t *testing.T) {
+	pm := pushMap{
+		"v0.2.0":      remote.Commits{commit4},
+		"v0.2.0-rc.1": remote.Commits{commit2},
+		"v0.2.0-rc.2": remote.Commits{commit3},
+		"v0.1.0-rc.1": remote.Commits{commit1},
+	}
+
+	tests := []struct {
+		name            string
+		tagName         string
+		expectedCommits remote.Commits
+	}{
+		{
+			name:            "Prerelease",
+			tagName:         "v0.2.0-rc.1",
+			expectedCommits: remote.Commits{commit2},
+		},
+		{
+			name:            "Release",
+			tagName:         "v0.2.0",
+			expectedCommits: remote.Commits{commit4, commit3, commit2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commits := rollupPrereleaseCommits(tc.tagName, pm)
+
+			assert.Equal(t, tc.expectedCommits, commits)
+		})
+	}
+}
+
+func TestAnnotatePrereleases(t *testing.T) {
+	release := changelog.Release{
+		IssueGroups: []changelog.IssueGroup{
+			{
+				Title: "Milestone 1.0",
+				Subgroups: []changelog.IssueGroup{
+					{
+						Title:  "Fixed Bugs",
+						Issues: []changelog.Issue{{Number: 1001}, {Number: 1002}},
+					},
+				},
+			},
+		},
+		MergeGroups: []changelog.MergeGroup{
+			{
+				Title:  "Merged Changes",
+				Merges: []changelog.Merge{{Number: 1003}, {Number: 1001}},
+			},
+		},
+		ChangeGroups: []changelog.ChangeGroup{
+			{
+				Title: "Other Changes",
+				Changes: []changelog.Change{
+					{Kind: changelog.ChangeKindMerge, Number: 1004},
+					{Kind: changelog.ChangeKindIssue, Number: 1004},
+				},
+			},
+		},
+	}
+
+	// Issue and merge numbers are not unique together
+	annotatePrereleases(&release, originMap{
+		{changelog.ChangeKindIssue, 1002}: "v0.2.0-rc.1",
+		{changelog.ChangeKindMerge, 1001}: "v0.2.0-rc.1",
+		{changelog.ChangeKindMerge, 1003}: "v0.2.0-rc.2",
+		{changelog.ChangeKindMerge, 1004}: "v0.2.0-beta.1",
+	})
+
+	assert.Equal(t, "", release.IssueGroups[0].Subgroups[0].Issues[0].Prerelease)
+	assert.Equal(t, "v0.2.0-rc.1", release.IssueGroups[0].Subgroups[0].Issues[1].Prerelease)
+	assert.Equal(t, "v0.2.0-rc.2", release.MergeGroups[0].Merges[0].Prerelease)
+	assert.Equal(t, "v0.2.0-rc.1", release.MergeGroups[0].Merges[1].Prerelease)
+	assert.Equal(t, "v0.2.0-beta.1", release.ChangeGroups[0].Changes[0].Prerelease)
+	assert.Equal(t, "", release.ChangeGroups[0].Changes[1].Prerelease)
+}
+
+func TestResolveSummary(t *testing.T) {
+	issue3 := issue1
+	issue3.Labels = remote.Labels{"summary"}
+	issue3.Body = "This release has a new feature.\r\n"
+
+	merge3 := merge1
+	merge3.Body = "Added a feature.\n\n```release-note\nAdded the -feature flag.\n```\n\n```release-notes\nThe feature can be enabled by a flag.\n```\n"
+
+	merge4 := merge2
+	merge4.Labels = remote.Labels{"summary"}
+	merge4.Body = "The whole description."
+
+	tests := []struct {
+		name            string
+		s               spec.Spec
+		issues          remote.Issues
+		merges          remote.Merges
+		expectedSummary string
+	}{
+		{
+			name:            "NoSummary",
+			s:               spec.Spec{},
+			issues:          remote.Issues{issue1, issue2},
+			merges:          remote.Merges{merge1, merge2},
+			expectedSummary: "",
+		},
+		{
+			name: "OK",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					SummaryLabels: []string{"summary"},
+				},
+				Merges: spec.Merges{
+					SummaryLabels: []string{"summary"},
+				},
+			},
+			issues:          remote.Issues{issue1, issue3},
+			merges:          remote.Merges{merge3, merge4},
+			expectedSummary: "This release has a new feature.\n\nThe feature can be enabled by a flag.\n\nThe whole description.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := resolveSummary(tc.s, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedSummary, summary)
+		})
+	}
+}
+
+func TestResolveBreakingChanges(t *testing.T) {
+	issue3 := issue1
+	issue3.Labels = remote.Labels{"breaking"}
+
+	issue4 := issue2
+	issue4.Body = "Details of the vulnerability.\r\n\r\nBREAKING CHANGE: The insecure mode is removed.\r\nUse the secure mode instead.\r\n\r\nMore details."
+
+	merge3 := merge1
+	merge3.Title = "feat(api)!: added a feature"
+
+	merge4 := merge2
+	merge4.Commit = remote.Commit{
+		Hash:    "20c5414eccaa147f2d6644de4ca36f35293fa43e",
+		Message: "refactor: refactored code\n\nBREAKING-CHANGE: The config file is renamed.",
+	}
+
+	tests := []struct {
+		name                    string
+		s                       spec.Spec
+		issues                  remote.Issues
+		merges                  remote.Merges
+		expectedBreakingChanges []changelog.BreakingChange
+	}{
+		{
+			name: "LabelsOnly",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					BreakingLabels: []string{"breaking"},
+				},
+			},
+			issues: remote.Issues{issue3, issue4},
+			merges: remote.Merges{merge3, merge4},
+			expectedBreakingChanges: []changelog.BreakingChange{
+				{Number: 1001, Title: "Found a bug", URL: "https://github.com/octocat/Hello-World/issues/1001"},
+			},
+		},
+		{
+			name: "AllRules",
+			s: spec.Spec{
+				Issues: spec.Issues{
+					BreakingLabels: []string{"breaking"},
+				},
+				Changes: spec.Changes{
+					Breaking: spec.Breaking{
+						Title:    true,
+						Body:     true,
+						Commit:   true,
+						Keywords: []string{"BREAKING CHANGE", "BREAKING-CHANGE"},
+					},
+				},
+			},
+			issues: remote.Issues{issue1, issue3, issue4},
+			merges: remote.Merges{merge2, merge3, merge4},
+			expectedBreakingChanges: []changelog.BreakingChange{
+				{Number: 1001, Title: "Found a bug", URL: "https://github.com/octocat/Hello-World/issues/1001"},
+				{Number: 1002, Title: "Discovered a vulnerability", URL: "https://github.com/octocat/Hello-World/issues/1002", Notes: "The insecure mode is removed.\nUse the secure mode instead."},
+				{Number: 1003, Title: "feat(api)!: added a feature", URL: "https://github.com/octocat/Hello-World/pull/1003"},
+				{Number: 1004, Title: "Refactored code", URL: "https://github.com/octocat/Hello-World/pull/1004", Notes: "The config file is renamed."},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			keywordRE := compileBreakingKeywords(tc.s.Changes.Breaking.Keywords)
+			breakingChanges := resolveBreakingChanges(tc.s, keywordRE, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedBreakingChanges, breakingChanges)
+		})
+	}
+}
+
+func TestResolveContributors(t *testing.T) {
+	merge3 := merge2
+	merge3.Commit = remote.Commit{
+		Hash:    "20c5414eccaa147f2d6644de4ca36f35293fa43e",
+		Message: "Refactored code\n\nCo-authored-by: The Octodog <octodog@github.com>\nCo-authored-by: The Octofox <octofox@github.com>",
+	}
+
+	tests := []struct {
+		name                 string
+		issues               remote.Issues
+		merges               remote.Merges
+		expectedContributors []changelog.Contributor
+	}{
+		{
+			name:                 "Empty",
+			issues:               remote.Issues{},
+			merges:               remote.Merges{},
+			expectedContributors: []changelog.Contributor{},
+		},
+		{
+			name:   "OK",
+			issues: remote.Issues{issue1, issue2},
+			merges: remote.Merges{merge1, merge3},
+			expectedContributors: []changelog.Contributor{
+				{
+					User: changelog.User{Name: "The Octocat", Username: "octocat", URL: "https://github.com/octocat"},
+				},
+				{
+					User: changelog.User{Name: "The Octodog", Username: "octodog", URL: "https://github.com/octodog"},
+				},
+				{
+					User: changelog.User{Name: "The Octofox"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			contributors := resolveContributors(tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedContributors, contributors)
+		})
+	}
+}
+
+func TestMarkFirstTimeContributors(t *testing.T) {
+	octocat := changelog.Contributor{
+		User: changelog.User{Username: "octocat"},
+	}
+
+	octodog := changelog.Contributor{
+		User: changelog.User{Username: "octodog"},
+	}
+
+	firstTime := func(c changelog.Contributor) changelog.Contributor {
+		c.FirstTime = true
+		return c
+	}
+
+	tests := []struct {
+		name             string
+		existing         []changelog.Release
+		new              []changelog.Release
+		expectedReleases []changelog.Release
+	}{
+		{
+			name:     "NoExistingRelease",
+			existing: []changelog.Release{},
+			new: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, octodog}},
+				{TagName: "v0.1.0", Contributors: []changelog.Contributor{octocat}},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, firstTime(octodog)}},
+				{TagName: "v0.1.0", Contributors: []changelog.Contributor{firstTime(octocat)}},
+			},
+		},
+		{
+			name: "ExistingReleasesWithoutContributors",
+			existing: []changelog.Release{
+				{TagName: "v0.1.0"},
+			},
+			new: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, octodog}},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, octodog}},
+			},
+		},
+		{
+			name: "ExistingReleasesWithContributors",
+			existing: []changelog.Release{
+				{TagName: "v0.1.0", Contributors: []changelog.Contributor{octocat}},
+			},
+			new: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, octodog}},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.1", Contributors: []changelog.Contributor{octocat, firstTime(octodog)}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			markFirstTimeContributors(tc.existing, tc.new)
+
+			assert.Equal(t, tc.expectedReleases, tc.new)
+		})
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	tests := []struct {
+		name             string
+		durations        []time.Duration
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "Empty",
+			durations:        []time.Duration{},
+			expectedDuration: 0,
+		},
+		{
+			name:             "Odd",
+			durations:        []time.Duration{5 * time.Hour, time.Hour, 3 * time.Hour},
+			expectedDuration: 3 * time.Hour,
+		},
+		{
+			name:             "Even",
+			durations:        []time.Duration{8 * time.Hour, time.Hour, 2 * time.Hour, 4 * time.Hour},
+			expectedDuration: 3 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := medianDuration(tc.durations)
+
+			assert.Equal(t, tc.expectedDuration, d)
+		})
+	}
+}
+
+func TestResolveStats(t *testing.T) {
+	openedIssue1 := issue1
+	openedIssue1.CreatedAt = t3.Add(-48 * time.Hour)
+
+	openedMerge1 := merge1
+	openedMerge1.CreatedAt = t3.Add(-2 * time.Hour)
+
+	openedMerge2 := merge2
+	openedMerge2.CreatedAt = t4.Add(-6 * time.Hour)
+
+	tests := []struct {
+		name          string
+		release       changelog.Release
+		issues        remote.Issues
+		merges        remote.Merges
+		expectedStats *changelog.Stats
+	}{
+		{
+			name:    "Empty",
+			release: changelog.Release{TagName: "v0.1.3"},
+			issues:  remote.Issues{},
+			merges:  remote.Merges{},
+			expectedStats: &changelog.Stats{
+				Groups: []changelog.GroupStats{},
+			},
+		},
+		{
+			name: "NoCreationTime",
+			release: changelog.Release{
+				TagName: "v0.1.3",
+				IssueGroups: []changelog.IssueGroup{
+					{Title: "Fixed Bugs", Issues: []changelog.Issue{changelogIssue1}},
+				},
+			},
+			issues: remote.Issues{issue1},
+			merges: remote.Merges{},
+			expectedStats: &changelog.Stats{
+				Issues: 1,
+				Groups: []changelog.GroupStats{
+					{Title: "Fixed Bugs", Issues: 1},
+				},
+				Contributors: 1,
+			},
+		},
+		{
+			name: "SplitLayout",
+			release: changelog.Release{
+				TagName: "v0.1.3",
+				IssueGroups: []changelog.IssueGroup{
+					{Title: "Fixed Bugs", Issues: []changelog.Issue{changelogIssue1}},
+				},
+				MergeGroups: []changelog.MergeGroup{
+					{
+						Title: "Milestone v1.0",
+						Subgroups: []changelog.MergeGroup{
+							{Title: "Enhancements", Merges: []changelog.Merge{changelogMerge1}},
+						},
+					},
+					{Title: "Merged Changes", Merges: []changelog.Merge{{Number: 1004}}},
+				},
+			},
+			issues: remote.Issues{openedIssue1},
+			merges: remote.Merges{openedMerge1, openedMerge2},
+			expectedStats: &changelog.Stats{
+				Issues: 1,
+				Merges: 2,
+				Groups: []changelog.GroupStats{
+					{Title: "Fixed Bugs", Issues: 1},
+					{Title: "Milestone v1.0", Merges: 1},
+					{Title: "Merged Changes", Merges: 1},
+				},
+				Contributors: 2,
+				LeadTime:     4 * time.Hour,
+				TimeToClose:  48 * time.Hour,
+			},
+		},
+		{
+			name: "UnifiedLayout",
+			release: changelog.Release{
+				TagName: "v0.1.3",
+				ChangeGroups: []changelog.ChangeGroup{
+					{
+						Title: "Fixed Bugs",
+						Changes: []changelog.Change{
+							{Kind: changelog.ChangeKindIssue, Number: 1001},
+						},
+						Subgroups: []changelog.ChangeGroup{
+							{
+								Title: "api",
+								Changes: []changelog.Change{
+									{Kind: changelog.ChangeKindMerge, Number: 1003},
+								},
+							},
+						},
+					},
+				},
+			},
+			issues: remote.Issues{openedIssue1},
+			merges: remote.Merges{openedMerge1},
+			expectedStats: &changelog.Stats{
+				Issues: 1,
+				Merges: 1,
+				Groups: []changelog.GroupStats{
+					{Title: "Fixed Bugs", Issues: 1, Merges: 1},
+				},
+				Contributors: 1,
+				LeadTime:     2 * time.Hour,
+				TimeToClose:  48 * time.Hour,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := resolveStats(tc.release, tc.issues, tc.merges)
+
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
+
+func TestResolvePreviousReleases(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         []changelog.Release
+		new              []changelog.Release
+		expectedReleases []changelog.Release
+	}{
+		{
+			name:     "NoExistingRelease",
+			existing: []changelog.Release{},
+			new: []changelog.Release{
+				{TagName: "v0.1.2", TagTime: t2, Stats: &changelog.Stats{}},
+				{TagName: "v0.1.1", TagTime: t1, Stats: &changelog.Stats{}},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.2", TagTime: t2, Stats: &changelog.Stats{Previous: "v0.1.1", Days: 10}},
+				{TagName: "v0.1.1", TagTime: t1, Stats: &changelog.Stats{}},
+			},
+		},
+		{
+			name: "WithExistingReleases",
+			existing: []changelog.Release{
+				{TagName: "v0.1.1", TagTime: t1},
+			},
+			new: []changelog.Release{
+				{TagName: "v0.1.3", TagTime: t3, Stats: &changelog.Stats{}},
+				{TagName: "v0.1.2", TagTime: t2},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.3", TagTime: t3, Stats: &changelog.Stats{Previous: "v0.1.2", Days: 10}},
+				{TagName: "v0.1.2", TagTime: t2},
+			},
+		},
+		{
+			name: "PreviousFromExistingRelease",
+			existing: []changelog.Release{
+				{TagName: "v0.1.1", TagTime: t1},
+			},
+			new: []changelog.Release{
+				{TagName: "v0.1.3", TagTime: t3, Stats: &changelog.Stats{}},
+			},
+			expectedReleases: []changelog.Release{
+				{TagName: "v0.1.3", TagTime: t3, Stats: &changelog.Stats{Previous: "v0.1.1", Days: 20}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolvePreviousReleases(tc.existing, tc.new)
+
+			assert.Equal(t, tc.expectedReleases, tc.new)
 		})
 	}
 }
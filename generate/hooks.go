@@ -0,0 +1,138 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/spec"
+)
+
+const (
+	hookPreGenerate = "pre-generate"
+	hookPostRender  = "post-render"
+	hookPostWrite   = "post-write"
+)
+
+// hookData is the data available to hooks.
+// It is written as JSON to the standard input of commands and passed to templates.
+type hookData struct {
+	Hook       string              `json:"hook"`
+	File       string              `json:"file"`
+	Content    string              `json:"content"`
+	Releases   []changelog.Release `json:"releases"`
+	Unreleased *changelog.Release  `json:"unreleased"`
+}
+
+// hookRunner runs the lifecycle hooks of generating changelogs.
+type hookRunner struct {
+	ui   ui.UI
+	file string
+}
+
+// Run runs a list of hooks in order and returns the content transformed by the template hooks.
+// Commands are run using sh with the hook data as JSON on stdin and the hook, file, and tag in environment variables.
+func (r *hookRunner) Run(ctx context.Context, hook string, hooks []spec.Hook, chlog *changelog.Changelog, content string) (string, error) {
+	for _, h := range hooks {
+		data := hookData{
+			Hook:       hook,
+			File:       r.file,
+			Content:    content,
+			Releases:   chlog.New,
+			Unreleased: chlog.Unreleased,
+		}
+
+		if h.Template != "" {
+			out, err := r.runTemplate(h.Template, data)
+			if err != nil {
+				return "", fmt.Errorf("%s hook: %s", hook, err)
+			}
+			content = out
+			continue
+		}
+
+		if err := r.runCommand(ctx, h.Command, data); err != nil {
+			return "", fmt.Errorf("%s hook %q: %s", hook, h.Command, err)
+		}
+	}
+
+	return content, nil
+}
+
+// replaceContent replaces the rendered content in a changelog file with the content transformed by hooks.
+func replaceContent(file, content, transformed string) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if !strings.Contains(string(b), content) {
+		return fmt.Errorf("rendered content not found in %s", file)
+	}
+
+	return os.WriteFile(file, []byte(strings.Replace(string(b), content, transformed, 1)), 0644)
+}
+
+func (r *hookRunner) runTemplate(text string, data hookData) (string, error) {
+	tmpl, err := template.New(data.Hook).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (r *hookRunner) runCommand(ctx context.Context, command string, data hookData) error {
+	// HTML characters are not escaped, so the Markdown content is readable as is
+	in := new(bytes.Buffer)
+	enc := json.NewEncoder(in)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return err
+	}
+
+	// The most recent new release is the one being released
+	var tag string
+	if len(data.Releases) > 0 {
+		tag = data.Releases[0].TagName
+	}
+
+	out := new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = in
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = append(os.Environ(),
+		"CHANGELOG_HOOK="+data.Hook,
+		"CHANGELOG_FILE="+data.File,
+		"CHANGELOG_TAG="+tag,
+	)
+
+	r.ui.Debugf(ui.Cyan, "Running %s hook: %s", data.Hook, command)
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(out.String()); msg != "" {
+			return fmt.Errorf("%s: %s", err, msg)
+		}
+		return err
+	}
+
+	if msg := strings.TrimSpace(out.String()); msg != "" {
+		r.ui.Debugf(ui.White, "%s", msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/spec"
+)
+
+func TestHookRunner_Run(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+
+	chlog := &changelog.Changelog{
+		New: []changelog.Release{
+			{TagName: "v0.2.0"},
+			{TagName: "v0.1.0"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		hook            string
+		hooks           []spec.Hook
+		content         string
+		expectedContent string
+		expectedOutput  string
+		expectedError   string
+	}{
+		{
+			name:            "NoHook",
+			hook:            hookPreGenerate,
+			hooks:           nil,
+			content:         "",
+			expectedContent: "",
+		},
+		{
+			name: "CommandFails",
+			hook: hookPreGenerate,
+			hooks: []spec.Hook{
+				{Command: "echo version file not found >&2; exit 1"},
+			},
+			expectedError: `pre-generate hook "echo version file not found >&2; exit 1": exit status 1: version file not found`,
+		},
+		{
+			name: "TemplateFails",
+			hook: hookPostRender,
+			hooks: []spec.Hook{
+				{Template: "{{ .Unknown }}"},
+			},
+			content:       "## v0.2.0\n",
+			expectedError: `post-render hook: template: post-render:1:3: executing "post-render" at <.Unknown>: can't evaluate field Unknown in type generate.hookData`,
+		},
+		{
+			name: "Environment",
+			hook: hookPostWrite,
+			hooks: []spec.Hook{
+				{Command: `echo "$CHANGELOG_HOOK $CHANGELOG_FILE $CHANGELOG_TAG" > ` + out},
+			},
+			content:         "## v0.2.0\n",
+			expectedContent: "## v0.2.0\n",
+			expectedOutput:  "post-write CHANGELOG.md v0.2.0\n",
+		},
+		{
+			name: "StdinAndTemplates",
+			hook: hookPostRender,
+			hooks: []spec.Hook{
+				{Template: "{{ .Content }}{{ range .Releases }}<!-- {{ .TagName }} -->\n{{ end }}"},
+				{Command: `grep -o '"content":"[^"]*"' > ` + out},
+			},
+			content:         "## v0.2.0\n",
+			expectedContent: "## v0.2.0\n<!-- v0.2.0 -->\n<!-- v0.1.0 -->\n",
+			expectedOutput:  `"content":"## v0.2.0\n<!-- v0.2.0 -->\n<!-- v0.1.0 -->\n"` + "\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &hookRunner{
+				ui:   ui.NewNop(),
+				file: "CHANGELOG.md",
+			}
+
+			content, err := r.Run(context.Background(), tc.hook, tc.hooks, chlog, tc.content)
+
+			if tc.expectedError != "" {
+				assert.Empty(t, content)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, content)
+
+				if tc.expectedOutput != "" {
+					b, err := os.ReadFile(out)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expectedOutput, string(b))
+				}
+			}
+		})
+	}
+}
+
+func TestReplaceContent(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "CHANGELOG.md")
+	err := os.WriteFile(file, []byte("# Changelog\n\n## v0.2.0\n\n## v0.1.0\n"), 0644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		file            string
+		content         string
+		transformed     string
+		expectedContent string
+		expectedError   string
+	}{
+		{
+			name:          "FileNotFound",
+			file:          filepath.Join(dir, "HISTORY.md"),
+			content:       "## v0.2.0\n",
+			transformed:   "## v0.2.0 :rocket:\n",
+			expectedError: "open " + filepath.Join(dir, "HISTORY.md") + ": no such file or directory",
+		},
+		{
+			name:          "ContentNotFound",
+			file:          file,
+			content:       "## v0.3.0\n",
+			transformed:   "## v0.3.0 :rocket:\n",
+			expectedError: "rendered content not found in " + file,
+		},
+		{
+			name:            "OK",
+			file:            file,
+			content:         "## v0.2.0\n",
+			transformed:     "## v0.2.0 :rocket:\n",
+			expectedContent: "# Changelog\n\n## v0.2.0 :rocket:\n\n## v0.1.0\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := replaceContent(tc.file, tc.content, tc.transformed)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+
+				b, err := os.ReadFile(tc.file)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+// LintRule is the rule violated by a problem in a changelog file.
+type LintRule string
+
+const (
+	// LintMalformedHeading is the rule for release headings that cannot be parsed.
+	LintMalformedHeading = LintRule("malformed-heading")
+	// LintDuplicateTag is the rule for releases with the same tag.
+	LintDuplicateTag = LintRule("duplicate-tag")
+	// LintOutOfOrder is the rule for releases that are not sorted from the most recent to the least recent.
+	LintOutOfOrder = LintRule("out-of-order")
+	// LintUnknownTag is the rule for releases with a tag that does not exist on the remote repository.
+	LintUnknownTag = LintRule("unknown-tag")
+	// LintMissingTag is the rule for tags on the remote repository without a release.
+	LintMissingTag = LintRule("missing-tag")
+	// LintTagURL is the rule for releases with a tag URL not matching the remote repository.
+	LintTagURL = LintRule("tag-url")
+	// LintCompareURL is the rule for releases with a compare URL not matching the remote repository.
+	LintCompareURL = LintRule("compare-url")
+)
+
+// LintProblem represents a problem found in a changelog file.
+// Line is the line number in the changelog file and it is only known for malformed headings.
+type LintProblem struct {
+	Rule    LintRule `json:"rule"`
+	Line    int      `json:"line,omitempty"`
+	Tag     string   `json:"tag,omitempty"`
+	Message string   `json:"message"`
+}
+
+// Lint checks the changelog file of a Git repository for problems instead of generating it.
+// The existing releases are checked for malformed headings, duplicate tags, chronological order,
+// tags that do not exist on the remote repository, and tag and compare URLs that do not match the remote repository.
+// The tags on the remote repository that are missing from the changelog file are also reported,
+// except for the excluded tags, the rolled-up pre-release tags, and the tags before the oldest release.
+func (g *Generator) Lint(ctx context.Context, s spec.Spec) ([]LintProblem, error) {
+	chlog, err := g.processor.Parse(changelog.ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.remoteRepo.CheckPermissions(ctx); err != nil {
+		return nil, err
+	}
+
+	tags, err := g.remoteRepo.FetchTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sortedTags, err := sortAndFilterTags(s.Tags, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pre-release tags are rolled up into their final release tags (or the latest pre-release tags), so they do not have their own releases
+	if s.Tags.Prerelease == spec.PrereleaseRollup {
+		names := sortedTags.Map(func(t remote.Tag) string {
+			return t.Name
+		})
+		sortedTags = rollupTags(sortedTags, names)
+	}
+
+	g.ui.Debugf(ui.Cyan, "Linting %d releases ...", len(chlog.Existing))
+
+	problems := []LintProblem{}
+	add := func(rule LintRule, line int, tag, format string, a ...interface{}) {
+		problems = append(problems, LintProblem{
+			Rule:    rule,
+			Line:    line,
+			Tag:     tag,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	for _, h := range chlog.Malformed {
+		add(LintMalformedHeading, h.Line, "", "release heading cannot be parsed: %s", h.Text)
+	}
+
+	seen := map[string]bool{}
+
+	for i, release := range chlog.Existing {
+		if seen[release.TagName] {
+			add(LintDuplicateTag, 0, release.TagName, "release is duplicated")
+			continue
+		}
+		seen[release.TagName] = true
+
+		// Releases are expected to be sorted from the most recent to the least recent
+		if i > 0 && release.TagTime.After(chlog.Existing[i-1].TagTime) {
+			add(LintOutOfOrder, 0, release.TagName, "release is more recent than the previous release %s", chlog.Existing[i-1].TagName)
+		}
+
+		// Excluded tags still exist on the remote repository
+		tag, ok := tags.Find(release.TagName)
+		if !ok {
+			add(LintUnknownTag, 0, release.TagName, "tag does not exist on the remote repository")
+			continue
+		}
+
+		if tag.WebURL != "" && release.TagURL != tag.WebURL {
+			add(LintTagURL, 0, release.TagName, "tag url %s does not match %s", release.TagURL, tag.WebURL)
+		}
+
+		if release.CompareURL == "" {
+			continue
+		}
+
+		// The least recent release is compared with the first commit
+		var baseRev string
+		if j := i + 1; j < len(chlog.Existing) {
+			baseRev = chlog.Existing[j].TagName
+		} else {
+			firstCommit, err := g.remoteRepo.FetchFirstCommit(ctx)
+			if err != nil {
+				return nil, err
+			}
+			baseRev = firstCommit.Hash
+		}
+
+		if compareURL := g.remoteRepo.CompareURL(baseRev, release.TagName); compareURL != "" && release.CompareURL != compareURL {
+			add(LintCompareURL, 0, release.TagName, "compare url %s does not match %s", release.CompareURL, compareURL)
+		}
+	}
+
+	// The tags before the oldest release are not expected in the changelog (i.e. the changelog is generated from a tag)
+	if l := len(chlog.Existing); l > 0 {
+		if i := sortedTags.Index(chlog.Existing[l-1].TagName); i >= 0 {
+			sortedTags = sortedTags[:i]
+		}
+	}
+
+	for _, tag := range sortedTags {
+		if !seen[tag.Name] {
+			add(LintMissingTag, 0, tag.Name, "tag is missing from the changelog")
+		}
+	}
+
+	if len(problems) == 0 {
+		g.ui.Infof(ui.Green, "No problem found in the changelog")
+	} else {
+		g.ui.Warnf(ui.Yellow, "Found %d problems in the changelog", len(problems))
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,324 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+func TestGenerator_Lint(t *testing.T) {
+	tag4 := remote.Tag{
+		Name:   "v0.2.0-rc.1",
+		Time:   t4,
+		Commit: commit4,
+		WebURL: "https://github.com/octocat/Hello-World/tree/v0.2.0-rc.1",
+	}
+
+	tag5 := remote.Tag{
+		Name:   "v0.1.2-rc.1",
+		Time:   t1,
+		Commit: commit1,
+		WebURL: "https://github.com/octocat/Hello-World/tree/v0.1.2-rc.1",
+	}
+
+	tests := []struct {
+		name             string
+		g                *Generator
+		ctx              context.Context
+		s                spec.Spec
+		expectedProblems []LintProblem
+		expectedError    string
+	}{
+		{
+			name: "ParseFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutError: errors.New("error on parsing the changelog file")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on parsing the changelog file",
+		},
+		{
+			name: "CheckPermissionsFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: errors.New("insufficient permissions")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "insufficient permissions",
+		},
+		{
+			name: "FetchTagsFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutError: errors.New("error on fetching tags")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on fetching tags",
+		},
+		{
+			name: "FetchFirstCommitFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Title: "Changelog",
+								Existing: []changelog.Release{
+									{
+										TagName:    "v0.1.1",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.1",
+										TagTime:    t1,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1",
+									},
+								},
+							},
+						},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutError: errors.New("error on fetching the first commit")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on fetching the first commit",
+		},
+		{
+			name: "NoProblem",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Title: "Changelog",
+								Existing: []changelog.Release{
+									{
+										TagName:    "v0.1.2",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.2",
+										TagTime:    t2,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2",
+									},
+									{
+										TagName:    "v0.1.1",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.1",
+										TagTime:    t1,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1",
+									},
+								},
+							},
+						},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1, tag2, tag3, tag5}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Exclude:    []string{"v0.1.3"},
+					Prerelease: spec.PrereleaseRollup,
+				},
+			},
+			expectedProblems: []LintProblem{},
+		},
+		{
+			name: "PendingPrerelease",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Title: "Changelog",
+								Existing: []changelog.Release{
+									{
+										TagName:    "v0.1.2",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.2",
+										TagTime:    t2,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2",
+									},
+									{
+										TagName:    "v0.1.1",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.1",
+										TagTime:    t1,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1",
+									},
+								},
+							},
+						},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1, tag2, tag3, tag4, tag5}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Exclude:    []string{"v0.1.3"},
+					Prerelease: spec.PrereleaseRollup,
+				},
+			},
+			expectedProblems: []LintProblem{
+				{Rule: LintMissingTag, Tag: "v0.2.0-rc.1", Message: "tag is missing from the changelog"},
+			},
+		},
+		{
+			name: "Problems",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{
+							OutChangelog: &changelog.Changelog{
+								Title: "Changelog",
+								Existing: []changelog.Release{
+									{
+										TagName: "v0.1.3",
+										TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.0",
+										TagTime: t3,
+									},
+									{
+										TagName:    "v0.1.1",
+										TagURL:     "https://github.com/octocat/Hello-World/tree/v0.1.1",
+										TagTime:    t1,
+										CompareURL: "https://github.com/octocat/Hello-World/compare/v0.1.0...v0.1.1",
+									},
+									{
+										TagName: "v0.1.2",
+										TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.2",
+										TagTime: t2,
+									},
+									{
+										TagName: "v0.1.2",
+										TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.2",
+										TagTime: t2,
+									},
+									{
+										TagName: "v0.1.0",
+										TagURL:  "https://github.com/octocat/Hello-World/tree/v0.1.0",
+										TagTime: t1,
+									},
+								},
+								Malformed: []changelog.Heading{
+									{Line: 42, Text: "## v0.0.1 (2020-10-01)"},
+								},
+							},
+						},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag1, tag2, tag3, tag4}},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.1"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s: spec.Spec{
+				Tags: spec.Tags{
+					Prerelease: spec.PrereleaseSeparate,
+				},
+			},
+			expectedProblems: []LintProblem{
+				{Rule: LintMalformedHeading, Line: 42, Message: "release heading cannot be parsed: ## v0.0.1 (2020-10-01)"},
+				{Rule: LintTagURL, Tag: "v0.1.3", Message: "tag url https://github.com/octocat/Hello-World/tree/v0.1.0 does not match https://github.com/octocat/Hello-World/tree/v0.1.3"},
+				{Rule: LintCompareURL, Tag: "v0.1.1", Message: "compare url https://github.com/octocat/Hello-World/compare/v0.1.0...v0.1.1 does not match https://github.com/octocat/Hello-World/compare/v0.1.2...v0.1.1"},
+				{Rule: LintOutOfOrder, Tag: "v0.1.2", Message: "release is more recent than the previous release v0.1.1"},
+				{Rule: LintDuplicateTag, Tag: "v0.1.2", Message: "release is duplicated"},
+				{Rule: LintUnknownTag, Tag: "v0.1.0", Message: "tag does not exist on the remote repository"},
+				{Rule: LintMissingTag, Tag: "v0.2.0-rc.1", Message: "tag is missing from the changelog"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			problems, err := tc.g.Lint(tc.ctx, tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedProblems, problems)
+			} else {
+				assert.Nil(t, problems)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"time"
 
-	"github.com/gardenbed/changelog/internal/changelog"
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
 )
 
 type (
 	GetRemoteMock struct {
+		InName    string
 		OutDomain string
 		OutPath   string
 		OutError  error
@@ -21,9 +22,10 @@ type (
 	}
 )
 
-func (m *MockGitRepo) GetRemote() (string, string, error) {
+func (m *MockGitRepo) GetRemote(name string) (string, string, error) {
 	i := m.GetRemoteIndex
 	m.GetRemoteIndex++
+	m.GetRemoteMocks[i].InName = name
 	return m.GetRemoteMocks[i].OutDomain, m.GetRemoteMocks[i].OutPath, m.GetRemoteMocks[i].OutError
 }
 
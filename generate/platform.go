@@ -0,0 +1,81 @@
+package generate
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/internal/remote/github"
+	"github.com/gardenbed/changelog/internal/remote/gitlab"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+// RemoteConfig has the configurations for creating a remote repository.
+// Clock and HTTPClient are nil unless they are set using the WithClock and WithHTTPClient options.
+// AnnotatedTags determines whether or not the metadata of annotated tags are needed along with the tags.
+// MergeCommits determines whether or not the commits of merges are needed along with the merges.
+type RemoteConfig struct {
+	UI            ui.UI
+	Path          string
+	AccessToken   string
+	Clock         func() time.Time
+	HTTPClient    *http.Client
+	AnnotatedTags bool
+	MergeCommits  bool
+}
+
+// RemoteFactory creates a remote repository for a platform.
+type RemoteFactory func(RemoteConfig) (remote.Repo, error)
+
+var platforms = struct {
+	sync.RWMutex
+	factories map[spec.Platform]RemoteFactory
+}{
+	factories: map[spec.Platform]RemoteFactory{
+		spec.PlatformGitHub: newGitHubRepo,
+		spec.PlatformGitLab: newGitLabRepo,
+	},
+}
+
+// RegisterPlatform registers a remote repository factory for a platform (i.e. github.com).
+// The factory replaces any other factory already registered for the same platform.
+func RegisterPlatform(platform spec.Platform, factory RemoteFactory) {
+	platforms.Lock()
+	defer platforms.Unlock()
+
+	platforms.factories[platform] = factory
+}
+
+// lookupPlatform returns the remote repository factory registered for a platform.
+func lookupPlatform(platform spec.Platform) (RemoteFactory, bool) {
+	platforms.RLock()
+	defer platforms.RUnlock()
+
+	factory, ok := platforms.factories[platform]
+	return factory, ok
+}
+
+// newGitHubRepo creates a remote repository for GitHub.
+// The GitHub client manages its own HTTP client, so an error is returned instead of ignoring the HTTPClient configuration.
+func newGitHubRepo(c RemoteConfig) (remote.Repo, error) {
+	if c.HTTPClient != nil {
+		return nil, errors.New("custom HTTP clients are not supported for GitHub repositories")
+	}
+
+	parts := strings.Split(c.Path, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("unexpected GitHub repository: cannot parse owner and repo")
+	}
+
+	return github.NewRepo(c.UI, parts[0], parts[1], c.AccessToken, c.Clock, c.AnnotatedTags, c.MergeCommits), nil
+}
+
+// newGitLabRepo creates a remote repository for GitLab.
+func newGitLabRepo(c RemoteConfig) (remote.Repo, error) {
+	return gitlab.NewRepo(c.UI, c.Path, c.AccessToken, c.HTTPClient), nil
+}
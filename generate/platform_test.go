@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+func TestRegisterPlatform(t *testing.T) {
+	const platform = spec.Platform("git.example.com")
+
+	defer func() {
+		platforms.Lock()
+		delete(platforms.factories, platform)
+		platforms.Unlock()
+	}()
+
+	_, ok := lookupPlatform(platform)
+	assert.False(t, ok)
+
+	var config RemoteConfig
+	RegisterPlatform(platform, func(c RemoteConfig) (remote.Repo, error) {
+		config = c
+		return &MockRemoteRepo{}, nil
+	})
+
+	factory, ok := lookupPlatform(platform)
+	assert.True(t, ok)
+	assert.NotNil(t, factory)
+
+	now := time.Now
+	client := &http.Client{}
+	s := spec.Spec{
+		Repo: spec.Repo{
+			Platform:    platform,
+			Path:        "octocat/Hello-World",
+			AccessToken: "access-token",
+		},
+	}
+
+	g, err := New(s, ui.NewNop(), WithClock(now), WithHTTPClient(client))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &MockRemoteRepo{}, g.remoteRepo)
+	assert.Equal(t, "octocat/Hello-World", config.Path)
+	assert.Equal(t, "access-token", config.AccessToken)
+	assert.NotNil(t, config.Clock)
+	assert.Same(t, client, config.HTTPClient)
+
+	// A failing factory
+	RegisterPlatform(platform, func(c RemoteConfig) (remote.Repo, error) {
+		return nil, errors.New("error on creating the remote repository")
+	})
+
+	g, err = New(s, ui.NewNop())
+
+	assert.Nil(t, g)
+	assert.EqualError(t, err, "error on creating the remote repository")
+}
+
+func TestNewGitHubRepo(t *testing.T) {
+	tests := []struct {
+		name          string
+		c             RemoteConfig
+		expectedError string
+	}{
+		{
+			name: "InvalidPath",
+			c: RemoteConfig{
+				UI:   ui.NewNop(),
+				Path: "octocat",
+			},
+			expectedError: "unexpected GitHub repository: cannot parse owner and repo",
+		},
+		{
+			name: "WithHTTPClient",
+			c: RemoteConfig{
+				UI:         ui.NewNop(),
+				Path:       "octocat/Hello-World",
+				HTTPClient: &http.Client{},
+			},
+			expectedError: "custom HTTP clients are not supported for GitHub repositories",
+		},
+		{
+			name: "Success",
+			c: RemoteConfig{
+				UI:          ui.NewNop(),
+				Path:        "octocat/Hello-World",
+				AccessToken: "access-token",
+				Clock:       time.Now,
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := newGitHubRepo(tc.c)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, r)
+			} else {
+				assert.Nil(t, r)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestNewGitLabRepo(t *testing.T) {
+	r, err := newGitLabRepo(RemoteConfig{
+		UI:         ui.NewNop(),
+		Path:       "octocat/Hello-World",
+		HTTPClient: &http.Client{},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, r)
+}
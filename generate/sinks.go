@@ -0,0 +1,234 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/spec"
+)
+
+// slackSectionLimit is the maximum length of the text in a Slack section block.
+const slackSectionLimit = 3000
+
+var (
+	mdLinkRegex    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRegex    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdHeadingRegex = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t]*$`)
+	mdItemRegex    = regexp.MustCompile(`(?m)^([ \t]*)- `)
+)
+
+// sinkData is the data available to the templates of sinks.
+type sinkData struct {
+	Content    string              `json:"content"`
+	Releases   []changelog.Release `json:"releases"`
+	Unreleased *changelog.Release  `json:"unreleased"`
+}
+
+// notifier sends notifications about new releases to sinks.
+type notifier struct {
+	ui     ui.UI
+	client *http.Client
+}
+
+// Notify sends the rendered content of new releases to a list of sinks in order.
+func (n *notifier) Notify(ctx context.Context, sinks []spec.Sink, chlog *changelog.Changelog, content string) error {
+	data := sinkData{
+		Content:    content,
+		Releases:   chlog.New,
+		Unreleased: chlog.Unreleased,
+	}
+
+	for _, sink := range sinks {
+		if err := n.notify(ctx, sink, data); err != nil {
+			return fmt.Errorf("%s sink: %s", sink.Type, err)
+		}
+
+		n.ui.Infof(ui.Green, "Sent a notification to the %s sink", sink.Type)
+	}
+
+	return nil
+}
+
+func (n *notifier) notify(ctx context.Context, sink spec.Sink, data sinkData) error {
+	message := data.Content
+	if sink.Template != "" {
+		tmpl, err := template.New(string(sink.Type)).Parse(sink.Template)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		if err := tmpl.Execute(buf, data); err != nil {
+			return err
+		}
+
+		message = buf.String()
+	}
+
+	var payload interface{}
+	switch sink.Type {
+	case spec.SinkSlack:
+		payload = slackPayload(notificationTitle(data), message)
+	case spec.SinkTeams:
+		payload = teamsPayload(notificationTitle(data), message)
+	default:
+		payload = webhookPayload(message, data)
+	}
+
+	return n.post(ctx, os.ExpandEnv(sink.URL), payload)
+}
+
+// post sends a JSON payload to a webhook.
+// The URL is not included in errors since webhook URLs are secrets.
+func (n *notifier) post(ctx context.Context, webhookURL string, payload interface{}) error {
+	body := new(bytes.Buffer)
+	enc := json.NewEncoder(body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(payload); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, body)
+	if err != nil {
+		return fmt.Errorf("invalid url")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return fmt.Errorf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if msg := strings.TrimSpace(string(b)); msg != "" {
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, msg)
+		}
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// notificationTitle returns the title of a notification for the new releases.
+func notificationTitle(data sinkData) string {
+	tags := []string{}
+	for _, r := range data.Releases {
+		tags = append(tags, r.TagName)
+	}
+
+	return "New Releases: " + strings.Join(tags, ", ")
+}
+
+// toSlackText converts Markdown to the Slack mrkdwn format.
+func toSlackText(md string) string {
+	text := mdLinkRegex.ReplaceAllString(md, "<$2|$1>")
+	text = mdBoldRegex.ReplaceAllString(text, "*$1*")
+	text = mdHeadingRegex.ReplaceAllString(text, "*$1*")
+	text = mdItemRegex.ReplaceAllString(text, "$1• ")
+
+	return strings.TrimSpace(text)
+}
+
+// splitText splits a text into chunks no longer than a limit at line boundaries if possible.
+func splitText(text string, limit int) []string {
+	chunks := []string{}
+	for len(text) > limit {
+		i := strings.LastIndex(text[:limit], "\n")
+		if i <= 0 {
+			// Do not split a multi-byte character
+			i = limit
+			for i > 0 && !utf8.RuneStart(text[i]) {
+				i--
+			}
+		}
+		chunks = append(chunks, text[:i])
+		text = strings.TrimLeft(text[i:], "\n")
+	}
+
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+
+	return chunks
+}
+
+// slackPayload creates a Slack message with a header block and section blocks.
+// See https://api.slack.com/messaging/webhooks
+func slackPayload(title, message string) map[string]interface{} {
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": title},
+		},
+	}
+
+	for _, chunk := range splitText(toSlackText(message), slackSectionLimit) {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": chunk},
+		})
+	}
+
+	return map[string]interface{}{
+		"text":   title,
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload creates a Microsoft Teams message with an adaptive card.
+// Adaptive cards do not support Markdown headings, so they are converted to bold texts.
+func teamsPayload(title, message string) map[string]interface{} {
+	text := strings.TrimSpace(mdHeadingRegex.ReplaceAllString(message, "**$1**"))
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []interface{}{
+						map[string]interface{}{"type": "TextBlock", "text": title, "weight": "Bolder", "size": "Medium", "wrap": true},
+						map[string]interface{}{"type": "TextBlock", "text": text, "wrap": true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// webhookPayload creates a raw JSON payload with the message and the new releases.
+func webhookPayload(message string, data sinkData) map[string]interface{} {
+	return map[string]interface{}{
+		"message":    message,
+		"content":    data.Content,
+		"releases":   data.Releases,
+		"unreleased": data.Unreleased,
+	}
+}
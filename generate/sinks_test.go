@@ -0,0 +1,181 @@
+package generate
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/spec"
+)
+
+const sinkContent = "## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)\n\n**Fixed Bugs:**\n\n  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001)\n"
+
+func TestNotifier_Notify(t *testing.T) {
+	chlog := &changelog.Changelog{
+		New: []changelog.Release{
+			{TagName: "v0.2.0"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		sink            spec.Sink
+		expectedPayload string
+		expectedError   string
+	}{
+		{
+			name:          "InvalidTemplate",
+			path:          "/slack",
+			sink:          spec.Sink{Type: spec.SinkSlack, Template: "{{ .Unknown }}"},
+			expectedError: `slack sink: template: slack:1:3: executing "slack" at <.Unknown>: can't evaluate field Unknown in type generate.sinkData`,
+		},
+		{
+			name:          "ServerError",
+			path:          "/error",
+			sink:          spec.Sink{Type: spec.SinkWebhook},
+			expectedError: "webhook sink: unexpected status 400: invalid_payload",
+		},
+		{
+			name: "Slack",
+			path: "/slack",
+			sink: spec.Sink{Type: spec.SinkSlack},
+			expectedPayload: `{
+				"text": "New Releases: v0.2.0",
+				"blocks": [
+					{ "type": "header", "text": { "type": "plain_text", "text": "New Releases: v0.2.0" } },
+					{ "type": "section", "text": { "type": "mrkdwn", "text": "*<https://github.com/octocat/Hello-World/tree/v0.2.0|v0.2.0> (2020-11-02)*\n\n*Fixed Bugs:*\n\n  • Fixed a bug <https://github.com/octocat/Hello-World/issues/1001|#1001>" } }
+				]
+			}`,
+		},
+		{
+			name: "Teams",
+			path: "/teams",
+			sink: spec.Sink{Type: spec.SinkTeams, Template: "{{ range .Releases }}Released {{ .TagName }}{{ end }}"},
+			expectedPayload: `{
+				"type": "message",
+				"attachments": [
+					{
+						"contentType": "application/vnd.microsoft.card.adaptive",
+						"content": {
+							"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+							"type": "AdaptiveCard",
+							"version": "1.4",
+							"body": [
+								{ "type": "TextBlock", "text": "New Releases: v0.2.0", "weight": "Bolder", "size": "Medium", "wrap": true },
+								{ "type": "TextBlock", "text": "Released v0.2.0", "wrap": true }
+							]
+						}
+					}
+				]
+			}`,
+		},
+		{
+			name: "Webhook",
+			path: "/webhook",
+			sink: spec.Sink{Type: spec.SinkWebhook},
+			expectedPayload: `{
+				"message": "## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)\n\n**Fixed Bugs:**\n\n  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001)\n",
+				"content": "## [v0.2.0](https://github.com/octocat/Hello-World/tree/v0.2.0) (2020-11-02)\n\n**Fixed Bugs:**\n\n  - Fixed a bug [#1001](https://github.com/octocat/Hello-World/issues/1001)\n",
+				"releases": [
+					{
+						"TagName": "v0.2.0", "TagURL": "", "TagTime": "0001-01-01T00:00:00Z", "ReleaseURL": "", "CompareURL": "", "TagMessage": "", "Summary": "",
+						"BreakingChanges": null, "IssueGroups": null, "MergeGroups": null, "ChangeGroups": null, "Commits": null, "Contributors": null, "Stats": null
+					}
+				],
+				"unreleased": null
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var payload string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				b, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				payload = string(b)
+
+				if r.URL.Path == "/error" {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte("invalid_payload"))
+				}
+			}))
+			defer ts.Close()
+
+			// The webhook URL is read from an environment variable
+			t.Setenv("SINK_URL", ts.URL+tc.path)
+			tc.sink.URL = "${SINK_URL}"
+
+			n := &notifier{
+				ui:     ui.NewNop(),
+				client: ts.Client(),
+			}
+
+			err := n.Notify(context.Background(), []spec.Sink{tc.sink}, chlog, sinkContent)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.JSONEq(t, tc.expectedPayload, payload)
+			}
+		})
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		limit          int
+		expectedChunks []string
+	}{
+		{
+			name:           "Empty",
+			text:           "",
+			limit:          10,
+			expectedChunks: []string{},
+		},
+		{
+			name:           "Short",
+			text:           "first line",
+			limit:          10,
+			expectedChunks: []string{"first line"},
+		},
+		{
+			name:           "LineBoundaries",
+			text:           "first\nsecond\nthird",
+			limit:          13,
+			expectedChunks: []string{"first\nsecond", "third"},
+		},
+		{
+			name:           "LongLine",
+			text:           strings.Repeat("é", 6),
+			limit:          5,
+			expectedChunks: []string{"éé", "éé", "éé"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := splitText(tc.text, tc.limit)
+			assert.Equal(t, tc.expectedChunks, chunks)
+
+			for _, chunk := range chunks {
+				assert.True(t, utf8.ValidString(chunk))
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package generate
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/spec"
+)
+
+// GroupStats is the number of issues and pull/merge requests in a top-level group of a release.
+type GroupStats struct {
+	Title  string `json:"title"`
+	Issues int    `json:"issues"`
+	Merges int    `json:"merges"`
+}
+
+// ReleaseStats are the metrics of a new release.
+// LeadTimeHours is the median time from opening to merging pull/merge requests
+// and TimeToCloseHours is the median time from opening to closing issues (zero if unknown).
+type ReleaseStats struct {
+	Tag               string       `json:"tag"`
+	Issues            int          `json:"issues"`
+	Merges            int          `json:"merges"`
+	Groups            []GroupStats `json:"groups"`
+	Contributors      int          `json:"contributors"`
+	Previous          string       `json:"previous,omitempty"`
+	DaysSincePrevious int          `json:"days_since_previous"`
+	LeadTimeHours     float64      `json:"lead_time_hours"`
+	TimeToCloseHours  float64      `json:"time_to_close_hours"`
+}
+
+// String returns a single line of the metrics in the same format as the stats line in the changelog.
+func (r ReleaseStats) String() string {
+	return changelog.Stats{
+		Issues:       r.Issues,
+		Merges:       r.Merges,
+		Contributors: r.Contributors,
+		Previous:     r.Previous,
+		Days:         r.DaysSincePrevious,
+		LeadTime:     time.Duration(r.LeadTimeHours * float64(time.Hour)),
+		TimeToClose:  time.Duration(r.TimeToCloseHours * float64(time.Hour)),
+	}.String()
+}
+
+// Stats resolves the metrics of the new releases (and the Unreleased release if enabled) without rendering the changelog.
+// The releases are sorted from the most recent to the least recent.
+func (g *Generator) Stats(ctx context.Context, s spec.Spec) ([]ReleaseStats, error) {
+	s.Content.Stats = true
+
+	chlog, _, err := g.resolve(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := []changelog.Release{}
+	if chlog.Unreleased != nil {
+		releases = append(releases, *chlog.Unreleased)
+	}
+	releases = append(releases, chlog.New...)
+
+	stats := []ReleaseStats{}
+	for _, r := range releases {
+		if r.Stats != nil {
+			stats = append(stats, toReleaseStats(r.TagName, r.Stats))
+		}
+	}
+
+	g.ui.Infof(ui.Green, "Resolved stats for %d releases", len(stats))
+
+	return stats, nil
+}
+
+func toReleaseStats(tag string, s *changelog.Stats) ReleaseStats {
+	groups := []GroupStats{}
+	for _, g := range s.Groups {
+		groups = append(groups, GroupStats{
+			Title:  g.Title,
+			Issues: g.Issues,
+			Merges: g.Merges,
+		})
+	}
+
+	return ReleaseStats{
+		Tag:               tag,
+		Issues:            s.Issues,
+		Merges:            s.Merges,
+		Groups:            groups,
+		Contributors:      s.Contributors,
+		Previous:          s.Previous,
+		DaysSincePrevious: s.Days,
+		LeadTimeHours:     s.LeadTime.Hours(),
+		TimeToCloseHours:  s.TimeToClose.Hours(),
+	}
+}
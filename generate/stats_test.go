@@ -0,0 +1,167 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/changelog"
+	"github.com/gardenbed/changelog/remote"
+	"github.com/gardenbed/changelog/spec"
+)
+
+func TestReleaseStats_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		stats          ReleaseStats
+		expectedString string
+	}{
+		{
+			name:           "Zero",
+			stats:          ReleaseStats{Tag: "v0.1.1"},
+			expectedString: "0 issues, 0 pull/merge requests, 0 contributors",
+		},
+		{
+			name: "OK",
+			stats: ReleaseStats{
+				Tag:               "v0.1.2",
+				Issues:            1,
+				Merges:            2,
+				Contributors:      2,
+				Previous:          "v0.1.1",
+				DaysSincePrevious: 10,
+				LeadTimeHours:     4.5,
+				TimeToCloseHours:  52,
+			},
+			expectedString: "1 issue, 2 pull/merge requests, 2 contributors, 10 days since v0.1.1, median lead time 4h 30m, median time to close 2d 4h",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.stats.String())
+		})
+	}
+}
+
+func TestGenerator_Stats(t *testing.T) {
+	tests := []struct {
+		name          string
+		g             *Generator
+		ctx           context.Context
+		s             spec.Spec
+		expectedStats []ReleaseStats
+		expectedError string
+	}{
+		{
+			name: "ParseFails",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutError: errors.New("error on parsing the changelog file")},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedError: "error on parsing the changelog file",
+		},
+		{
+			name: "NoNewTag",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{}},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			s:             spec.Spec{},
+			expectedStats: []ReleaseStats{},
+		},
+		{
+			name: "Success",
+			g: &Generator{
+				ui: ui.NewNop(),
+				processor: &MockChangelogProcessor{
+					ParseMocks: []ParseMock{
+						{OutChangelog: &changelog.Changelog{Title: "Changelog"}},
+					},
+				},
+				remoteRepo: &MockRemoteRepo{
+					CheckPermissionsMocks: []CheckPermissionsMock{
+						{OutError: nil},
+					},
+					FetchDefaultBranchMocks: []FetchDefaultBranchMock{
+						{OutBranch: branch},
+					},
+					FetchTagsMocks: []FetchTagsMock{
+						{OutTags: remote.Tags{tag2, tag1}},
+					},
+					FetchFirstCommitMocks: []FetchFirstCommitMock{
+						{OutCommit: commit1},
+					},
+					FetchParentCommitsMocks: []FetchParentCommitsMock{
+						{OutCommits: remote.Commits{commit3, commit2, commit1}},
+						{OutCommits: remote.Commits{commit2, commit1}},
+						{OutCommits: remote.Commits{commit1}},
+					},
+					FetchIssuesAndMergesMocks: []FetchIssuesAndMergesMock{
+						{
+							OutIssues: remote.Issues{},
+							OutMerges: remote.Merges{},
+						},
+					},
+					CompareURLMocks: []CompareURLMock{
+						{OutString: "https://github.com/octocat/Hello-World/compare/v0.1.1...v0.1.2"},
+						{OutString: "https://github.com/octocat/Hello-World/compare/25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378...v0.1.1"},
+					},
+				},
+			},
+			ctx: context.Background(),
+			s:   spec.Spec{},
+			expectedStats: []ReleaseStats{
+				{
+					Tag:               "v0.1.2",
+					Groups:            []GroupStats{},
+					Previous:          "v0.1.1",
+					DaysSincePrevious: 10,
+				},
+				{
+					Tag:    "v0.1.1",
+					Groups: []GroupStats{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats, err := tc.g.Stats(tc.ctx, tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStats, stats)
+			} else {
+				assert.Nil(t, stats)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
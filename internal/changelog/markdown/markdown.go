@@ -1,193 +0,0 @@
-// Package markdown provides functionality to process changelogs in Markdown format.
-package markdown
-
-import (
-	"bufio"
-	"bytes"
-	"fmt"
-	"html/template"
-	"os"
-	"regexp"
-	"strings"
-	"time"
-
-	"github.com/gardenbed/charm/ui"
-
-	"github.com/gardenbed/changelog/internal/changelog"
-)
-
-const timeLayout = "2006-01-02"
-
-const emptyTemplate = `# {{title .Title}}
-
-**DO NOT MODIFY THIS FILE!**
-*This changelog is automatically generated by [changelog](https://github.com/gardenbed/changelog)*
-
-
-`
-
-const changelogTemplate = `{{range .}}## [{{.TagName}}]({{.TagURL}}) ({{time .TagTime}})
-{{if .ReleaseURL}}
-{{.ReleaseURL}}
-{{end}}
-[Compare Changes]({{.CompareURL}})
-
-{{range .IssueGroups}}**{{title .Title}}:**
-
-{{range .Issues}}  - {{.Title}} [#{{.Number}}]({{.URL}}) ({{if ne .OpenedBy.Username .ClosedBy.Username}}[{{.OpenedBy.Username}}]({{.OpenedBy.URL}}), {{end}}[{{.ClosedBy.Username}}]({{.ClosedBy.URL}}))
-{{end}}
-{{end}}{{range .MergeGroups}}**{{title .Title}}:**
-
-{{range .Merges}}  - {{.Title}} [#{{.Number}}]({{.URL}}) ({{if ne .OpenedBy.Username .MergedBy.Username}}[{{.OpenedBy.Username}}]({{.OpenedBy.URL}}), {{end}}[{{.MergedBy.Username}}]({{.MergedBy.URL}}))
-{{end}}
-{{end}}
-{{end}}`
-
-var (
-	h1Regex = regexp.MustCompile(`^# ([0-9A-Za-z-_]+)$`)
-	h2Regex = regexp.MustCompile(`^## \[([0-9A-Za-z-.]+)\]\(([0-9A-Za-z-.:/]+)\) \((\d{4}-\d{2}-\d{2})\)$`)
-
-	funcMap = template.FuncMap{
-		"title": strings.Title, // nolint directives: sa1019
-		"time": func(t time.Time) string {
-			return t.Format(timeLayout)
-		},
-	}
-)
-
-// processor implements the changelog.Processor interface for Markdown format.
-type processor struct {
-	ui            ui.UI
-	baseFile      string
-	changelogFile string
-	content       string
-}
-
-// NewProcessor creates a new changelog processor for Markdown format.
-func NewProcessor(ui ui.UI, baseFile, changelogFile string) changelog.Processor {
-	return &processor{
-		ui:            ui,
-		baseFile:      baseFile,
-		changelogFile: changelogFile,
-	}
-}
-
-func (p *processor) createChangelog() (*changelog.Changelog, error) {
-	chlog := changelog.NewChangelog()
-
-	tmpl, _ := template.New("changelog").Funcs(funcMap).Parse(emptyTemplate)
-	buf := new(bytes.Buffer)
-	_ = tmpl.Execute(buf, chlog)
-	p.content = buf.String()
-
-	p.ui.Warnf(ui.Yellow, "%s not found", p.changelogFile)
-	p.ui.Infof(ui.Green, "A new changelog is created.")
-
-	return chlog, nil
-}
-
-func (p *processor) Parse(opts changelog.ParseOptions) (*changelog.Changelog, error) {
-	p.ui.Debugf(ui.Cyan, "Opening %s ...", p.changelogFile)
-
-	f, err := os.Open(p.changelogFile)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return p.createChangelog()
-		}
-		return nil, err
-	}
-
-	defer func() {
-		_ = f.Close()
-	}()
-
-	p.ui.Debugf(ui.Cyan, "Parsing %s ...", p.changelogFile)
-
-	content := ""
-	chlog := new(changelog.Changelog)
-
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		content += fmt.Sprintln(line)
-
-		if sm := h1Regex.FindStringSubmatch(line); len(sm) == 2 {
-			chlog.Title = sm[1]
-		} else if sm := h2Regex.FindStringSubmatch(line); len(sm) == 4 {
-			t, err := time.Parse(timeLayout, sm[3])
-			if err != nil {
-				return nil, err
-			}
-
-			chlog.Existing = append(chlog.Existing, changelog.Release{
-				TagName: sm[1],
-				TagURL:  sm[2],
-				TagTime: t,
-			})
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	p.content = content
-
-	p.ui.Infof(ui.Green, "Successfully parsed %s", p.changelogFile)
-
-	return chlog, nil
-}
-
-func (p *processor) Render(chlog *changelog.Changelog) (string, error) {
-	p.ui.Debugf(ui.Cyan, "Updating the changelog ...")
-
-	// ==============================> RENDER THE CONTENT FOR NEW RELEASES <==============================
-
-	// All parameters are pre-defined and we do not expect an error here
-	tmpl, _ := template.New("changelog").Funcs(funcMap).Parse(changelogTemplate)
-
-	buf := new(bytes.Buffer)
-	if err := tmpl.Execute(buf, chlog.New); err != nil {
-		return "", err
-	}
-
-	newContent := buf.String()
-
-	// ==============================> UPDATE THE CHANGELOG FILE <==============================
-
-	f, err := os.OpenFile(p.changelogFile, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return "", err
-	}
-
-	defer func() {
-		_ = f.Close()
-	}()
-
-	if i := strings.Index(p.content, "##"); i >= 0 {
-		p.content = p.content[:i] + newContent + p.content[i:]
-	} else {
-		// Add the content of an optional base file if generating the changelog for the first time
-		var baseContent string
-		if p.baseFile != "" {
-			p.ui.Infof(ui.Green, "Adding the base file content to the changelog: %s", p.baseFile)
-
-			b, err := os.ReadFile(p.baseFile)
-			if err != nil {
-				return "", err
-			}
-
-			baseContent = string(b)
-		}
-
-		p.content += newContent + baseContent
-	}
-
-	if _, err = f.WriteString(p.content); err != nil {
-		return "", err
-	}
-
-	p.ui.Infof(ui.Green, "Successfully updated the changelog: %s", p.changelogFile)
-
-	return newContent, nil
-}
@@ -0,0 +1,364 @@
+// Package filter provides a small expression language for filtering issues and pull/merge requests.
+//
+// An expression is made of terms combined with the !, &&, and || operators and grouped with parentheses.
+// Each term compares a field of a change with a value using one of the following operators:
+//
+//	field:value     The field is equal to the value (same as ==)
+//	field==value    The field is equal to the value
+//	field!=value    The field is not equal to the value
+//	field~value     The field matches the value as a POSIX-compliant regex
+//	field!~value    The field does not match the value as a POSIX-compliant regex
+//
+// The supported fields are label, author, milestone, title, and body.
+// For label, a term is true if any of the labels satisfies it (or none of the labels for != and !~).
+// Values can be bare words or double-quoted strings.
+//
+// Example:
+//
+//	label:bug && !label:internal && author!~"bot" && milestone=="1.2"
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+// Expr is a parsed filter expression.
+type Expr interface {
+	// Match determines whether or not a change satisfies the expression.
+	Match(remote.Change) bool
+	String() string
+}
+
+// Parse parses a filter expression.
+func Parse(expr string) (Expr, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid filter expression: unexpected %q at position %d", t.text, t.pos)
+	}
+
+	return e, nil
+}
+
+type (
+	notExpr struct {
+		expr Expr
+	}
+
+	andExpr struct {
+		left, right Expr
+	}
+
+	orExpr struct {
+		left, right Expr
+	}
+
+	termExpr struct {
+		field string
+		op    string
+		value string
+		re    *regexp.Regexp
+	}
+)
+
+func (e *notExpr) Match(c remote.Change) bool {
+	return !e.expr.Match(c)
+}
+
+func (e *notExpr) String() string {
+	return "!" + e.expr.String()
+}
+
+func (e *andExpr) Match(c remote.Change) bool {
+	return e.left.Match(c) && e.right.Match(c)
+}
+
+func (e *andExpr) String() string {
+	return fmt.Sprintf("(%s && %s)", e.left, e.right)
+}
+
+func (e *orExpr) Match(c remote.Change) bool {
+	return e.left.Match(c) || e.right.Match(c)
+}
+
+func (e *orExpr) String() string {
+	return fmt.Sprintf("(%s || %s)", e.left, e.right)
+}
+
+func (e *termExpr) Match(c remote.Change) bool {
+	var values []string
+
+	switch e.field {
+	case "label":
+		values = c.Labels
+	case "author":
+		values = []string{c.Author.Username}
+	case "milestone":
+		values = []string{c.Milestone}
+	case "title":
+		values = []string{c.Title}
+	case "body":
+		values = []string{c.Body}
+	}
+
+	// Negative operators are satisfied only if no value satisfies the positive operator
+	switch e.op {
+	case "!=":
+		return !anyOf(values, func(v string) bool { return v == e.value })
+	case "!~":
+		return !anyOf(values, e.re.MatchString)
+	case "~":
+		return anyOf(values, e.re.MatchString)
+	default:
+		return anyOf(values, func(v string) bool { return v == e.value })
+	}
+}
+
+func (e *termExpr) String() string {
+	return fmt.Sprintf("%s%s%q", e.field, e.op, e.value)
+}
+
+func anyOf(values []string, f func(string) bool) bool {
+	for _, v := range values {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+var fields = map[string]bool{
+	"label":     true,
+	"author":    true,
+	"milestone": true,
+	"title":     true,
+	"body":      true,
+}
+
+// ==============================> LEXER <==============================
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+
+		case strings.HasPrefix(s[i:], "&&"):
+			tokens = append(tokens, token{tokenAnd, "&&", i})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, token{tokenOr, "||", i})
+			i += 2
+
+		case strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="), strings.HasPrefix(s[i:], "!~"):
+			tokens = append(tokens, token{tokenOp, s[i : i+2], i})
+			i += 2
+
+		case c == ':' || c == '~':
+			tokens = append(tokens, token{tokenOp, string(c), i})
+			i++
+
+		case c == '!':
+			tokens = append(tokens, token{tokenNot, "!", i})
+			i++
+
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, fmt.Errorf("invalid filter expression: unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{tokenString, b.String(), i})
+			i = j + 1
+
+		default:
+			j := i
+			for ; j < len(s) && !strings.ContainsRune(" \t\n\r()&|!=:~\"", rune(s[j])); j++ {
+			}
+			if j == i {
+				return nil, fmt.Errorf("invalid filter expression: unexpected %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{tokenWord, s[i:j], i})
+			i = j
+		}
+	}
+
+	tokens = append(tokens, token{tokenEOF, "end of expression", len(s)})
+
+	return tokens, nil
+}
+
+// ==============================> PARSER <==============================
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// or := and ( "||" and )*
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// and := unary ( "&&" unary )*
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// unary := "!" unary | "(" or ")" | term
+func (p *parser) parseUnary() (Expr, error) {
+	switch t := p.next(); t.kind {
+	case tokenNot:
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: e}, nil
+
+	case tokenLParen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("invalid filter expression: expected \")\" at position %d", t.pos)
+		}
+		return e, nil
+
+	case tokenWord:
+		return p.parseTerm(t)
+
+	default:
+		return nil, fmt.Errorf("invalid filter expression: unexpected %q at position %d", t.text, t.pos)
+	}
+}
+
+// term := field op value
+func (p *parser) parseTerm(field token) (Expr, error) {
+	if !fields[field.text] {
+		return nil, fmt.Errorf("invalid filter expression: unknown field %q at position %d", field.text, field.pos)
+	}
+
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, fmt.Errorf("invalid filter expression: expected operator after %q at position %d", field.text, op.pos)
+	}
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, fmt.Errorf("invalid filter expression: expected value after %q at position %d", field.text+op.text, value.pos)
+	}
+
+	term := &termExpr{
+		field: field.text,
+		op:    op.text,
+		value: value.text,
+	}
+
+	if op.text == ":" {
+		term.op = "=="
+	}
+
+	if op.text == "~" || op.text == "!~" {
+		re, err := regexp.CompilePOSIX(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter expression: %s at position %d", err, value.pos)
+		}
+		term.re = re
+	}
+
+	return term, nil
+}
@@ -0,0 +1,183 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+var (
+	change1 = remote.Change{
+		Number:    1001,
+		Title:     "Fixed a bug",
+		Body:      "The bug was in the parser.",
+		Labels:    []string{"bug"},
+		Milestone: "1.2",
+		Author: remote.User{
+			Username: "octocat",
+		},
+	}
+
+	change2 = remote.Change{
+		Number:    1002,
+		Title:     "docs: fixed a typo",
+		Labels:    []string{"bug", "internal"},
+		Milestone: "1.2",
+		Author: remote.User{
+			Username: "octodog",
+		},
+	}
+
+	change3 = remote.Change{
+		Number: 1003,
+		Title:  "Bump a dependency",
+		Labels: []string{"dependencies"},
+		Author: remote.User{
+			Username: "dependabot[bot]",
+		},
+	}
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		expr           string
+		expectedError  string
+		expectedString string
+	}{
+		{
+			name:          "Empty",
+			expr:          "",
+			expectedError: `invalid filter expression: unexpected "end of expression" at position 0`,
+		},
+		{
+			name:          "UnterminatedString",
+			expr:          `title~"^docs`,
+			expectedError: "invalid filter expression: unterminated string at position 6",
+		},
+		{
+			name:          "UnknownField",
+			expr:          "state:closed",
+			expectedError: `invalid filter expression: unknown field "state" at position 0`,
+		},
+		{
+			name:          "MissingOperator",
+			expr:          "label bug",
+			expectedError: `invalid filter expression: expected operator after "label" at position 6`,
+		},
+		{
+			name:          "MissingValue",
+			expr:          "label: && author:octocat",
+			expectedError: `invalid filter expression: expected value after "label:" at position 7`,
+		},
+		{
+			name:          "MissingParenthesis",
+			expr:          "(label:bug || label:security",
+			expectedError: `invalid filter expression: expected ")" at position 28`,
+		},
+		{
+			name:          "UnexpectedToken",
+			expr:          "label:bug label:security",
+			expectedError: `invalid filter expression: unexpected "label" at position 10`,
+		},
+		{
+			name:          "InvalidRegex",
+			expr:          "title~[",
+			expectedError: "invalid filter expression: error parsing regexp: missing closing ]: `[` at position 6",
+		},
+		{
+			name:           "Term",
+			expr:           "label:bug",
+			expectedString: `label=="bug"`,
+		},
+		{
+			name:           "Precedence",
+			expr:           `label:bug && !label:internal || author!~"bot" && milestone=="1.2"`,
+			expectedString: `((label=="bug" && !label=="internal") || (author!~"bot" && milestone=="1.2"))`,
+		},
+		{
+			name:           "Parentheses",
+			expr:           `label:bug && (title ~ "^docs" || body != "")`,
+			expectedString: `(label=="bug" && (title~"^docs" || body!=""))`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Parse(tc.expr)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, expr)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedString, expr.String())
+			}
+		})
+	}
+}
+
+func TestExpr_Match(t *testing.T) {
+	tests := []struct {
+		name            string
+		expr            string
+		changes         []remote.Change
+		expectedMatches []bool
+	}{
+		{
+			name:            "LabelEqual",
+			expr:            "label:bug",
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, true, false},
+		},
+		{
+			name:            "LabelNotEqual",
+			expr:            "label!=internal",
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, false, true},
+		},
+		{
+			name:            "AuthorNotMatch",
+			expr:            `author!~"bot"`,
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, true, false},
+		},
+		{
+			name:            "TitleMatch",
+			expr:            `title ~ "^docs"`,
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{false, true, false},
+		},
+		{
+			name:            "BodyMatch",
+			expr:            `body~parser`,
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, false, false},
+		},
+		{
+			name:            "Combined",
+			expr:            `label:bug && !label:internal && author!~"bot" && milestone=="1.2"`,
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, false, false},
+		},
+		{
+			name:            "Or",
+			expr:            `milestone==1.2 || label:dependencies`,
+			changes:         []remote.Change{change1, change2, change3},
+			expectedMatches: []bool{true, true, true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Parse(tc.expr)
+			assert.NoError(t, err)
+
+			for i, c := range tc.changes {
+				assert.Equal(t, tc.expectedMatches[i], expr.Match(c), "change %d", c.Number)
+			}
+		})
+	}
+}
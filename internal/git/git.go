@@ -2,11 +2,16 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gardenbed/charm/ui"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 var (
@@ -21,7 +26,17 @@ var (
 
 // Repo is a Git repository.
 type Repo interface {
-	GetRemote() (string, string, error)
+	GetRemote(string) (string, string, error)
+	GetAnnotatedTags() ([]AnnotatedTag, error)
+}
+
+// AnnotatedTag is an annotated tag in a Git repository.
+type AnnotatedTag struct {
+	Name        string
+	Message     string
+	TaggerName  string
+	TaggerEmail string
+	TaggedAt    time.Time
 }
 
 type repo struct {
@@ -48,34 +63,114 @@ func NewRepo(u ui.UI, path string) (Repo, error) {
 }
 
 // GetRemote returns the domain part and path part of a Git remote repository URL.
-// It assumes the remote repository is named origin.
-func (r *repo) GetRemote() (string, string, error) {
+// If no remote name is given, the origin remote is used or the only remote if there is no origin remote.
+// All URLs of the remote (including push URLs) are tried in order and the first supported one is used.
+func (r *repo) GetRemote(name string) (string, string, error) {
 	r.ui.Debugf(ui.Cyan, "Reading git remote URL ...")
 
-	// TODO: Should we handle all remote names and not just the origin?
-	remote, err := r.git.Remote("origin")
+	remote, err := r.findRemote(name)
 	if err != nil {
 		return "", "", err
 	}
 
-	// TODO: Should we handle all URLs and not just the first one?
-	var remoteURL string
-	if config := remote.Config(); len(config.URLs) > 0 {
-		remoteURL = config.URLs[0]
+	config := remote.Config()
+	r.ui.Debugf(ui.Cyan, "Git remote found: %s", config.Name)
+
+	for _, remoteURL := range config.URLs {
+		// Parse the remote URL into a domain part a path part
+		if matches := httpsRE.FindStringSubmatch(remoteURL); len(matches) == 6 {
+			// Git remote url is using HTTPS protocol
+			// Example: https://github.com/gardenbed/changelog.git --> matches = []string{"https://github.com/gardenbed/changelog.git", "github.com", "gardenbed/changelog", "gardenbed/", "changelog", ".git"}
+			r.ui.Infof(ui.Green, "Git remote URL: %s", remoteURL)
+			return matches[1], matches[2], nil
+		} else if matches := sshRE.FindStringSubmatch(remoteURL); len(matches) == 6 {
+			// Git remote url is using SSH protocol
+			// Example: git@github.com:gardenbed/changelog.git --> matches = []string{"git@github.com:gardenbed/changelog.git", "github.com", "gardenbed/changelog, "gardenbed/", "changelog", ".git"}
+			r.ui.Infof(ui.Green, "Git remote URL: %s", remoteURL)
+			return matches[1], matches[2], nil
+		}
+
+		r.ui.Debugf(ui.Cyan, "Git remote URL skipped: %s", remoteURL)
+	}
+
+	return "", "", fmt.Errorf("invalid git remote url: %s", strings.Join(config.URLs, ", "))
+}
+
+// findRemote returns a Git remote by its name.
+// If the name is empty, it falls back to the origin remote or the only remote.
+func (r *repo) findRemote(name string) (*git.Remote, error) {
+	if name != "" {
+		remote, err := r.git.Remote(name)
+		if errors.Is(err, git.ErrRemoteNotFound) {
+			return nil, fmt.Errorf("git remote not found: %s", name)
+		}
+		return remote, err
+	}
+
+	remote, err := r.git.Remote("origin")
+	if err == nil {
+		return remote, nil
+	} else if !errors.Is(err, git.ErrRemoteNotFound) {
+		return nil, err
+	}
+
+	remotes, err := r.git.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(remotes) {
+	case 0:
+		return nil, errors.New("no git remote found")
+	case 1:
+		return remotes[0], nil
+	}
+
+	names := []string{}
+	for _, remote := range remotes {
+		names = append(names, remote.Config().Name)
+	}
+	sort.Strings(names)
+
+	return nil, fmt.Errorf("no origin git remote found among multiple git remotes: %s", strings.Join(names, ", "))
+}
+
+// GetAnnotatedTags returns all annotated tags in a Git repository.
+// Lightweight tags do not have tag objects and they are skipped.
+func (r *repo) GetAnnotatedTags() ([]AnnotatedTag, error) {
+	r.ui.Debugf(ui.Cyan, "Reading git annotated tags ...")
+
+	refs, err := r.git.Tags()
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse the origin remote URL into a domain part a path part
-	if matches := httpsRE.FindStringSubmatch(remoteURL); len(matches) == 6 {
-		// Git remote url is using HTTPS protocol
-		// Example: https://github.com/gardenbed/changelog.git --> matches = []string{"https://github.com/gardenbed/changelog.git", "github.com", "gardenbed/changelog", "gardenbed/", "changelog", ".git"}
-		r.ui.Infof(ui.Green, "Git remote URL: %s", remoteURL)
-		return matches[1], matches[2], nil
-	} else if matches := sshRE.FindStringSubmatch(remoteURL); len(matches) == 6 {
-		// Git remote url is using SSH protocol
-		// Example: git@github.com:gardenbed/changelog.git --> matches = []string{"git@github.com:gardenbed/changelog.git", "github.com", "gardenbed/changelog, "gardenbed/", "changelog", ".git"}
-		r.ui.Infof(ui.Green, "Git remote URL: %s", remoteURL)
-		return matches[1], matches[2], nil
+	tags := []AnnotatedTag{}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		obj, err := r.git.TagObject(ref.Hash())
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		tags = append(tags, AnnotatedTag{
+			Name:        ref.Name().Short(),
+			Message:     obj.Message,
+			TaggerName:  obj.Tagger.Name,
+			TaggerEmail: obj.Tagger.Email,
+			TaggedAt:    obj.Tagger.When,
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return "", "", fmt.Errorf("invalid git remote url: %s", remoteURL)
+	r.ui.Debugf(ui.Cyan, "Git annotated tags are read: %d", len(tags))
+
+	return tags, nil
 }
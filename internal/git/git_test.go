@@ -1,10 +1,14 @@
 package git
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gardenbed/charm/ui"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,31 +54,89 @@ func TestNewRepo(t *testing.T) {
 }
 
 func TestRepo_GetRemote(t *testing.T) {
-	g, err := git.PlainOpen("../..")
-	assert.NoError(t, err)
-
 	tests := []struct {
 		name           string
+		dir            string
+		config         string
+		remote         string
 		expectedDomain string
 		expectedPath   string
 		expectedError  string
 	}{
 		{
 			name:           "OK",
+			dir:            "../..",
 			expectedDomain: "github.com",
 			expectedPath:   "gardenbed/changelog",
 			expectedError:  "",
 		},
+		{
+			name:          "NoRemote",
+			config:        "",
+			expectedError: "no git remote found",
+		},
+		{
+			name:          "RemoteNotFound",
+			config:        "[remote \"origin\"]\n\turl = https://github.com/octodog/Hello-World.git\n",
+			remote:        "upstream",
+			expectedError: "git remote not found: upstream",
+		},
+		{
+			name:          "MultipleRemotesWithoutOrigin",
+			config:        "[remote \"upstream\"]\n\turl = https://github.com/octocat/Hello-World.git\n[remote \"fork\"]\n\turl = https://github.com/octodog/Hello-World.git\n",
+			expectedError: "no origin git remote found among multiple git remotes: fork, upstream",
+		},
+		{
+			name:          "InvalidURL",
+			config:        "[remote \"origin\"]\n\turl = /srv/git/Hello-World.git\n",
+			expectedError: "invalid git remote url: /srv/git/Hello-World.git",
+		},
+		{
+			name:           "OnlyRemote",
+			config:         "[remote \"upstream\"]\n\turl = https://github.com/octocat/Hello-World.git\n",
+			expectedDomain: "github.com",
+			expectedPath:   "octocat/Hello-World",
+		},
+		{
+			name:           "NamedRemote",
+			config:         "[remote \"origin\"]\n\turl = https://github.com/octodog/Hello-World.git\n[remote \"upstream\"]\n\turl = git@github.com:octocat/Hello-World.git\n",
+			remote:         "upstream",
+			expectedDomain: "github.com",
+			expectedPath:   "octocat/Hello-World",
+		},
+		{
+			name:           "PushURL",
+			config:         "[remote \"origin\"]\n\turl = /srv/git/Hello-World.git\n\tpushurl = git@github.com:octocat/Hello-World.git\n",
+			expectedDomain: "github.com",
+			expectedPath:   "octocat/Hello-World",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			dir := tc.dir
+			if dir == "" {
+				// The remotes are configured in a new Git repository
+				dir = t.TempDir()
+				_, err := git.PlainInit(dir, false)
+				assert.NoError(t, err)
+
+				f, err := os.OpenFile(filepath.Join(dir, ".git", "config"), os.O_APPEND|os.O_WRONLY, 0644)
+				assert.NoError(t, err)
+				_, err = f.WriteString(tc.config)
+				assert.NoError(t, err)
+				assert.NoError(t, f.Close())
+			}
+
+			g, err := git.PlainOpen(dir)
+			assert.NoError(t, err)
+
 			r := &repo{
 				ui:  ui.NewNop(),
 				git: g,
 			}
 
-			domain, path, err := r.GetRemote()
+			domain, path, err := r.GetRemote(tc.remote)
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
@@ -88,3 +150,49 @@ func TestRepo_GetRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestRepo_GetAnnotatedTags(t *testing.T) {
+	taggedAt := time.Date(2020, time.October, 28, 10, 0, 0, 0, time.UTC)
+	signature := &object.Signature{
+		Name:  "The Octocat",
+		Email: "octocat@github.com",
+		When:  taggedAt,
+	}
+
+	g, err := git.PlainInit(t.TempDir(), false)
+	assert.NoError(t, err)
+
+	wt, err := g.Worktree()
+	assert.NoError(t, err)
+
+	hash, err := wt.Commit("Initial commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            signature,
+	})
+	assert.NoError(t, err)
+
+	_, err = g.CreateTag("v0.1.0", hash, &git.CreateTagOptions{
+		Tagger:  signature,
+		Message: "The first release.",
+	})
+	assert.NoError(t, err)
+
+	// Lightweight tag
+	_, err = g.CreateTag("v0.1.1", hash, nil)
+	assert.NoError(t, err)
+
+	r := &repo{
+		ui:  ui.NewNop(),
+		git: g,
+	}
+
+	tags, err := r.GetAnnotatedTags()
+
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "v0.1.0", tags[0].Name)
+	assert.Equal(t, "The first release.\n", tags[0].Message)
+	assert.Equal(t, "The Octocat", tags[0].TaggerName)
+	assert.Equal(t, "octocat@github.com", tags[0].TaggerEmail)
+	assert.True(t, taggedAt.Equal(tags[0].TaggedAt))
+}
@@ -0,0 +1,114 @@
+// Package jira provides a minimal client for the Jira REST API.
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrNotFound is returned when an issue does not exist or is not visible to the user.
+var ErrNotFound = errors.New("jira issue not found")
+
+// Issue represents a Jira issue.
+type Issue struct {
+	Key         string
+	Type        string
+	Summary     string
+	FixVersions []string
+	WebURL      string
+}
+
+type (
+	issueResponse struct {
+		Key    string      `json:"key"`
+		Fields issueFields `json:"fields"`
+	}
+
+	issueFields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		FixVersions []struct {
+			Name string `json:"name"`
+		} `json:"fixVersions"`
+	}
+)
+
+// Client is a client for a Jira-compatible REST API.
+type Client struct {
+	client  *http.Client
+	baseURL string
+	user    string
+	token   string
+}
+
+// NewClient creates a new Jira client.
+// If the user is set, the token is used for basic authentication (Jira Cloud API tokens).
+// Otherwise, the token is used as a bearer token (Jira Server and Data Center personal access tokens).
+// A new HTTP client is created if the given client is nil.
+func NewClient(baseURL, user, token string, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &Client{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		user:    user,
+		token:   token,
+	}
+}
+
+// GetIssue retrieves an issue by its key (i.e. PROJ-1234).
+func (c *Client) GetIssue(ctx context.Context, key string) (Issue, error) {
+	u := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=issuetype,summary,fixVersions", c.baseURL, url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Issue{}, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Issue{}, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return Issue{}, fmt.Errorf("GET %s %d", u, resp.StatusCode)
+	}
+
+	ir := new(issueResponse)
+	if err := json.NewDecoder(resp.Body).Decode(ir); err != nil {
+		return Issue{}, fmt.Errorf("invalid jira issue %s: %s", key, err)
+	}
+
+	fixVersions := []string{}
+	for _, v := range ir.Fields.FixVersions {
+		fixVersions = append(fixVersions, v.Name)
+	}
+
+	return Issue{
+		Key:         ir.Key,
+		Type:        ir.Fields.IssueType.Name,
+		Summary:     ir.Fields.Summary,
+		FixVersions: fixVersions,
+		WebURL:      fmt.Sprintf("%s/browse/%s", c.baseURL, ir.Key),
+	}, nil
+}
@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const issueBody = `{
+  "key": "PROJ-1234",
+  "fields": {
+    "summary": "Support SSO login",
+    "issuetype": { "name": "Story" },
+    "fixVersions": [ { "name": "1.2.0" } ]
+  }
+}`
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		user    string
+		token   string
+		client  *http.Client
+	}{
+		{
+			name:    "OK",
+			baseURL: "https://example.atlassian.net/",
+			user:    "octocat@example.com",
+			token:   "jira-token",
+		},
+		{
+			name:    "WithHTTPClient",
+			baseURL: "https://example.atlassian.net",
+			token:   "jira-token",
+			client:  &http.Client{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClient(tc.baseURL, tc.user, tc.token, tc.client)
+
+			assert.NotNil(t, c)
+			assert.NotNil(t, c.client)
+			if tc.client != nil {
+				assert.Same(t, tc.client, c.client)
+			}
+			assert.Equal(t, "https://example.atlassian.net", c.baseURL)
+			assert.Equal(t, tc.user, c.user)
+			assert.Equal(t, tc.token, c.token)
+		})
+	}
+}
+
+func TestClient_GetIssue(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          string
+		token         string
+		key           string
+		expectedAuth  string
+		expectedIssue Issue
+		expectedError string
+	}{
+		{
+			name:          "NotFound",
+			token:         "jira-token",
+			key:           "PROJ-404",
+			expectedAuth:  "Bearer jira-token",
+			expectedError: "jira issue not found",
+		},
+		{
+			name:          "ServerError",
+			token:         "jira-token",
+			key:           "PROJ-500",
+			expectedAuth:  "Bearer jira-token",
+			expectedError: "/rest/api/2/issue/PROJ-500?fields=issuetype,summary,fixVersions 500",
+		},
+		{
+			name:          "InvalidResponse",
+			key:           "PROJ-1",
+			expectedError: "invalid jira issue PROJ-1: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:         "BearerAuth",
+			token:        "jira-token",
+			key:          "PROJ-1234",
+			expectedAuth: "Bearer jira-token",
+			expectedIssue: Issue{
+				Key:         "PROJ-1234",
+				Type:        "Story",
+				Summary:     "Support SSO login",
+				FixVersions: []string{"1.2.0"},
+			},
+		},
+		{
+			name:         "BasicAuth",
+			user:         "octocat",
+			token:        "jira-token",
+			key:          "PROJ-1234",
+			expectedAuth: "Basic b2N0b2NhdDpqaXJhLXRva2Vu",
+			expectedIssue: Issue{
+				Key:         "PROJ-1234",
+				Type:        "Story",
+				Summary:     "Support SSO login",
+				FixVersions: []string{"1.2.0"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expectedAuth, r.Header.Get("Authorization"))
+
+				switch r.URL.Path {
+				case "/rest/api/2/issue/PROJ-404":
+					w.WriteHeader(http.StatusNotFound)
+				case "/rest/api/2/issue/PROJ-500":
+					w.WriteHeader(http.StatusInternalServerError)
+				case "/rest/api/2/issue/PROJ-1":
+					_, _ = w.Write([]byte("not json"))
+				default:
+					_, _ = w.Write([]byte(issueBody))
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(ts.URL, tc.user, tc.token, ts.Client())
+			issue, err := c.GetIssue(context.Background(), tc.key)
+
+			if tc.expectedError != "" {
+				assert.Empty(t, issue)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				tc.expectedIssue.WebURL = ts.URL + "/browse/PROJ-1234"
+				assert.Equal(t, tc.expectedIssue, issue)
+			}
+		})
+	}
+}
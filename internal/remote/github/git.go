@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardenbed/go-github"
+)
+
+type (
+	// gitRef is a GitHub Git reference object.
+	gitRef struct {
+		Ref    string    `json:"ref"`
+		Object gitObject `json:"object"`
+	}
+
+	// gitObject is a GitHub Git object that a reference points to.
+	// Type is tag for annotated tags and commit for lightweight tags.
+	gitObject struct {
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	}
+
+	// gitTag is a GitHub Git annotated tag object.
+	gitTag struct {
+		Tag     string    `json:"tag"`
+		SHA     string    `json:"sha"`
+		Message string    `json:"message"`
+		Tagger  gitTagger `json:"tagger"`
+	}
+
+	// gitTagger is the author of a GitHub Git annotated tag object.
+	gitTagger struct {
+		Name  string    `json:"name"`
+		Email string    `json:"email"`
+		Date  time.Time `json:"date"`
+	}
+)
+
+// TagName returns the tag name of a tag reference.
+func (r gitRef) TagName() string {
+	return strings.TrimPrefix(r.Ref, "refs/tags/")
+}
+
+// IsAnnotatedTag determines if a reference points to an annotated tag object.
+func (r gitRef) IsAnnotatedTag() bool {
+	return r.Object.Type == "tag"
+}
+
+// gitDataService provides access to the GitHub Git database API.
+// This API is not covered by the github.RepoService.
+type gitDataService struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+// TagRefs retrieves the tag references of a repository.
+// See https://docs.github.com/en/rest/git/refs#list-matching-references
+func (s *gitDataService) TagRefs(ctx context.Context, pageSize, pageNo int) ([]gitRef, *github.Response, error) {
+	url := fmt.Sprintf("/repos/%s/%s/git/matching-refs/tags", s.owner, s.repo)
+	req, err := s.client.NewPageRequest(ctx, "GET", url, pageSize, pageNo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refs := []gitRef{}
+
+	resp, err := s.client.Do(req, &refs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return refs, resp, nil
+}
+
+// Tag retrieves an annotated tag object by its hash.
+// See https://docs.github.com/en/rest/git/tags#get-a-tag
+func (s *gitDataService) Tag(ctx context.Context, sha string) (*gitTag, *github.Response, error) {
+	url := fmt.Sprintf("/repos/%s/%s/git/tags/%s", s.owner, s.repo, sha)
+	req, err := s.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tag := new(gitTag)
+
+	resp, err := s.client.Do(req, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tag, resp, nil
+}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	gitHubTagRefsBody = `[
+		{
+			"ref": "refs/tags/v0.1.0",
+			"object": { "type": "tag", "sha": "940bd336248efae0f9ee5bc7b2d5c985887b16ac" }
+		}
+	]`
+
+	gitHubTagBody = `{
+		"tag": "v0.1.0",
+		"sha": "940bd336248efae0f9ee5bc7b2d5c985887b16ac",
+		"message": "The first release.\n",
+		"tagger": { "name": "The Octocat", "email": "octocat@github.com", "date": "2020-10-28T10:00:00Z" }
+	}`
+)
+
+func newTestGitDataService(t *testing.T, handler http.HandlerFunc) (*gitDataService, func()) {
+	ts := httptest.NewServer(handler)
+
+	client, err := github.NewEnterpriseClient(ts.URL, ts.URL, ts.URL, "")
+	assert.NoError(t, err)
+
+	s := &gitDataService{
+		client: client,
+		owner:  "octocat",
+		repo:   "Hello-World",
+	}
+
+	return s, ts.Close
+}
+
+func TestGitRef(t *testing.T) {
+	assert.Equal(t, "v0.1.0", gitHubTagRef.TagName())
+	assert.True(t, gitHubTagRef.IsAnnotatedTag())
+	assert.False(t, gitRef{Object: gitObject{Type: "commit"}}.IsAnnotatedTag())
+}
+
+func TestGitDataService_TagRefs(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedRefs  []gitRef
+		expectedError bool
+	}{
+		{
+			name:          "Fails",
+			statusCode:    http.StatusNotFound,
+			body:          `{ "message": "Not Found" }`,
+			expectedError: true,
+		},
+		{
+			name:         "Success",
+			statusCode:   http.StatusOK,
+			body:         gitHubTagRefsBody,
+			expectedRefs: []gitRef{gitHubTagRef},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, close := newTestGitDataService(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/octocat/Hello-World/git/matching-refs/tags", r.URL.Path)
+				assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+				assert.Equal(t, "1", r.URL.Query().Get("page"))
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			})
+			defer close()
+
+			refs, resp, err := s.TagRefs(context.Background(), 100, 1)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, refs)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+				assert.Equal(t, tc.expectedRefs, refs)
+			}
+		})
+	}
+}
+
+func TestGitDataService_Tag(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedTag   *gitTag
+		expectedError bool
+	}{
+		{
+			name:          "Fails",
+			statusCode:    http.StatusNotFound,
+			body:          `{ "message": "Not Found" }`,
+			expectedError: true,
+		},
+		{
+			name:        "Success",
+			statusCode:  http.StatusOK,
+			body:        gitHubTagBody,
+			expectedTag: &gitHubAnnotatedTag,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, close := newTestGitDataService(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/octocat/Hello-World/git/tags/940bd336248efae0f9ee5bc7b2d5c985887b16ac", r.URL.Path)
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			})
+			defer close()
+
+			tag, resp, err := s.Tag(context.Background(), "940bd336248efae0f9ee5bc7b2d5c985887b16ac")
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, tag)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+				assert.Equal(t, tc.expectedTag, tag)
+			}
+		})
+	}
+}
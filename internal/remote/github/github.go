@@ -11,11 +11,14 @@ import (
 	"github.com/gardenbed/charm/ui"
 	"github.com/gardenbed/go-github"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 const pageSize = 100
 
+// maxConcurrentRequests is the maximum number of API calls made in parallel for fetching individual objects.
+const maxConcurrentRequests = 10
+
 type (
 	githubService interface {
 		EnsureScopes(context.Context, ...github.Scope) error
@@ -37,14 +40,26 @@ type (
 		List(context.Context, int, int, github.IssuesFilter) ([]github.Issue, *github.Response, error)
 		Events(context.Context, int, int, int) ([]github.Event, *github.Response, error)
 	}
+
+	gitService interface {
+		TagRefs(context.Context, int, int) ([]gitRef, *github.Response, error)
+		Tag(context.Context, string) (*gitTag, *github.Response, error)
+	}
+
+	pullService interface {
+		Commits(context.Context, int, int, int) ([]github.Commit, *github.Response, error)
+	}
 )
 
 // repo implements the remote.Repo interface for GitHub.
 type repo struct {
-	ui     ui.UI
-	owner  string
-	repo   string
-	stores struct {
+	ui            ui.UI
+	now           func() time.Time
+	owner         string
+	repo          string
+	annotatedTags bool
+	mergeCommits  bool
+	stores        struct {
 		users   *store
 		commits *store
 	}
@@ -53,18 +68,32 @@ type repo struct {
 		users  usersService
 		repo   repoService
 		issues issueService
+		git    gitService
+		pulls  pullService
 	}
 }
 
 // NewRepo creates a new GitHub repository.
-func NewRepo(ui ui.UI, ownerName, repoName, accessToken string) remote.Repo {
+// The clock is used for the time of future tags and it defaults to time.Now if not set.
+// Annotated tag objects are only fetched along with the tags if annotatedTags is true,
+// since they require an extra API call per annotated tag.
+// The commits of pull requests are only fetched along with the pull requests if mergeCommits is true,
+// since they require an extra API call per merged pull request.
+func NewRepo(ui ui.UI, ownerName, repoName, accessToken string, clock func() time.Time, annotatedTags, mergeCommits bool) remote.Repo {
+	if clock == nil {
+		clock = time.Now
+	}
+
 	client := github.NewClient(accessToken)
 	repoService := client.Repo(ownerName, repoName)
 
 	r := &repo{
-		ui:    ui,
-		owner: ownerName,
-		repo:  repoName,
+		ui:            ui,
+		now:           clock,
+		owner:         ownerName,
+		repo:          repoName,
+		annotatedTags: annotatedTags,
+		mergeCommits:  mergeCommits,
 	}
 
 	r.stores.users = newStore()
@@ -73,6 +102,16 @@ func NewRepo(ui ui.UI, ownerName, repoName, accessToken string) remote.Repo {
 	r.services.users = client.Users
 	r.services.repo = repoService
 	r.services.issues = repoService.Issues
+	r.services.git = &gitDataService{
+		client: client,
+		owner:  ownerName,
+		repo:   repoName,
+	}
+	r.services.pulls = &pullDataService{
+		client: client,
+		owner:  ownerName,
+		repo:   repoName,
+	}
 
 	return r
 }
@@ -153,11 +192,31 @@ func (r *repo) findEvent(ctx context.Context, num int, name string) (github.Even
 	return github.Event{}, nil
 }
 
+func (r *repo) getPullCommits(ctx context.Context, num int) ([]github.Commit, error) {
+	commits := []github.Commit{}
+
+	for p := 1; p > 0; {
+		page, resp, err := r.services.pulls.Commits(ctx, num, pageSize, p)
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, page...)
+
+		// resp.Pages.Next == 0 is not a valid page number and causes the loop to exit
+		p = resp.Pages.Next
+	}
+
+	r.ui.Debugf(ui.Cyan, "Fetched %d commits for pull request %d", len(commits), num)
+
+	return commits, nil
+}
+
 // FutureTag returns a tag that does not exist yet for a GitHub repository.
 func (r *repo) FutureTag(name string) remote.Tag {
 	return remote.Tag{
 		Name:   name,
-		Time:   time.Now(),
+		Time:   r.now(),
 		WebURL: fmt.Sprintf("https://github.com/%s/%s/tree/%s", r.owner, r.repo, name),
 	}
 }
@@ -304,15 +363,92 @@ func (r *repo) FetchTags(ctx context.Context) (remote.Tags, error) {
 		return nil, err
 	}
 
+	// ==============================> FETCH ANNOTATED TAGS <==============================
+
+	annotationStore := newStore()
+	if r.annotatedTags {
+		if annotationStore, err = r.fetchAnnotatedTags(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	// ==============================> JOINING TAGS & COMMITS <==============================
 
-	tags := resolveTags(tagStore, r.stores.commits, r.owner, r.repo)
+	tags := resolveTags(tagStore, r.stores.commits, annotationStore, r.owner, r.repo)
 
 	r.ui.Debugf(ui.Cyan, "GitHub tags are fetched: %d", len(tags))
 
 	return tags, nil
 }
 
+// fetchAnnotatedTags retrieves the tag objects of all annotated tags for a GitHub repository.
+// The returned store maps tag names to tag objects.
+func (r *repo) fetchAnnotatedTags(ctx context.Context) (*store, error) {
+	r.ui.Debugf(ui.Cyan, "Fetching GitHub annotated tags ...")
+
+	refStore := newStore()
+
+	// Fetch tag references
+	r.ui.Debugf(ui.Cyan, "Fetched GitHub tag references page 1 ...")
+	gitHubRefs, resp, err := r.services.git.TagRefs(ctx, pageSize, 1)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range gitHubRefs {
+		refStore.Save(ref.TagName(), ref)
+	}
+
+	g3, ctx3 := errgroup.WithContext(ctx)
+	g3.SetLimit(maxConcurrentRequests)
+
+	// Fetch more tag references if any
+	for p := 2; p <= resp.Pages.Last; p++ {
+		p := p // https://golang.org/doc/faq#closures_and_goroutines
+		g3.Go(func() error {
+			r.ui.Debugf(ui.Cyan, "Fetched GitHub tag references page %d ...", p)
+			gitHubRefs, _, err := r.services.git.TagRefs(ctx3, pageSize, p)
+			if err != nil {
+				return err
+			}
+			for _, ref := range gitHubRefs {
+				refStore.Save(ref.TagName(), ref)
+			}
+			return nil
+		})
+	}
+
+	if err := g3.Wait(); err != nil {
+		return nil, err
+	}
+
+	annotationStore := newStore()
+
+	g4, ctx4 := errgroup.WithContext(ctx)
+	g4.SetLimit(maxConcurrentRequests)
+
+	// Fetch tag objects for annotated tags (lightweight tags do not have tag objects)
+	_ = refStore.ForEach(func(k, v interface{}) error {
+		ref := v.(gitRef)
+		if ref.IsAnnotatedTag() {
+			g4.Go(func() error {
+				t, _, err := r.services.git.Tag(ctx4, ref.Object.SHA)
+				if err != nil {
+					return err
+				}
+				annotationStore.Save(ref.TagName(), *t)
+				return nil
+			})
+		}
+		return nil
+	})
+
+	if err := g4.Wait(); err != nil {
+		return nil, err
+	}
+
+	return annotationStore, nil
+}
+
 // FetchIssuesAndMerges retrieves all closed issues and merged pull requests for a GitHub repository.
 func (r *repo) FetchIssuesAndMerges(ctx context.Context, since time.Time) (remote.Issues, remote.Merges, error) {
 	if since.IsZero() {
@@ -368,6 +504,7 @@ func (r *repo) FetchIssuesAndMerges(ctx context.Context, since time.Time) (remot
 	r.ui.Debugf(ui.Cyan, "Fetching GitHub events and commits for issues and pull requests ...")
 
 	eventStore := newStore()
+	pullCommitStore := newStore()
 
 	g2, ctx2 := errgroup.WithContext(ctx)
 
@@ -400,6 +537,14 @@ func (r *repo) FetchIssuesAndMerges(ctx context.Context, since time.Time) (remot
 				if _, err := r.getCommit(ctx2, e.CommitID); err != nil {
 					return err
 				}
+
+				if r.mergeCommits {
+					commits, err := r.getPullCommits(ctx2, num)
+					if err != nil {
+						return err
+					}
+					pullCommitStore.Save(num, commits)
+				}
 			}
 
 			return nil
@@ -447,7 +592,7 @@ func (r *repo) FetchIssuesAndMerges(ctx context.Context, since time.Time) (remot
 
 	// ==============================> JOINING ISSUES, PULLS, EVENTS, COMMITS, & USERS <==============================
 
-	issues, merges := resolveIssuesAndMerges(issueStore, eventStore, r.stores.commits, r.stores.users)
+	issues, merges := resolveIssuesAndMerges(issueStore, eventStore, pullCommitStore, r.stores.commits, r.stores.users)
 
 	r.ui.Debugf(ui.Cyan, "Resolved and sorted GitHub issues (%d) and pull requests (%d)", len(issues), len(merges))
 	r.ui.Infof(ui.Green, "All GitHub issues (%d) and pull requests (%d) are fetched", len(issues), len(merges))
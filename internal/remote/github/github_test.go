@@ -10,7 +10,7 @@ import (
 	"github.com/gardenbed/go-github"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 func TestNewRepo(t *testing.T) {
@@ -32,20 +32,24 @@ func TestNewRepo(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			r := NewRepo(tc.ui, tc.ownerName, tc.repoName, tc.accessToken)
+			r := NewRepo(tc.ui, tc.ownerName, tc.repoName, tc.accessToken, nil, true, true)
 			assert.NotNil(t, r)
 
 			gr, ok := r.(*repo)
 			assert.True(t, ok)
 
 			assert.Equal(t, tc.ui, gr.ui)
+			assert.NotNil(t, gr.now)
 			assert.Equal(t, tc.ownerName, gr.owner)
 			assert.Equal(t, tc.repoName, gr.repo)
+			assert.True(t, gr.annotatedTags)
+			assert.True(t, gr.mergeCommits)
 			assert.NotNil(t, gr.stores.users)
 			assert.NotNil(t, gr.stores.commits)
 			assert.NotNil(t, gr.services.github)
 			assert.NotNil(t, gr.services.users)
 			assert.NotNil(t, gr.services.repo)
+			assert.NotNil(t, gr.services.pulls)
 		})
 	}
 }
@@ -375,6 +379,68 @@ func TestRepo_findEvent(t *testing.T) {
 	}
 }
 
+func TestRepo_getPullCommits(t *testing.T) {
+	tests := []struct {
+		name            string
+		pullService     *MockPullService
+		ctx             context.Context
+		num             int
+		expectedCommits []github.Commit
+		expectedError   string
+	}{
+		{
+			name: "Error",
+			pullService: &MockPullService{
+				CommitsMocks: []PullCommitsMock{
+					{OutError: errors.New("error on getting github pull commits")},
+				},
+			},
+			ctx:           context.Background(),
+			num:           1002,
+			expectedError: "error on getting github pull commits",
+		},
+		{
+			name: "Success",
+			pullService: &MockPullService{
+				CommitsMocks: []PullCommitsMock{
+					{
+						OutCommits: []github.Commit{gitHubCommit1},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 0, Prev: 0, Next: 2, Last: 2},
+						},
+					},
+					{
+						OutCommits: []github.Commit{gitHubCommit2},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 1, Prev: 1, Next: 0, Last: 0},
+						},
+					},
+				},
+			},
+			ctx:             context.Background(),
+			num:             1002,
+			expectedCommits: []github.Commit{gitHubCommit1, gitHubCommit2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &repo{ui: ui.NewNop()}
+			r.services.pulls = tc.pullService
+
+			commits, err := r.getPullCommits(tc.ctx, tc.num)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, commits)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCommits, commits)
+			}
+		})
+	}
+}
+
 func TestRepo_FutureTag(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -396,8 +462,11 @@ func TestRepo_FutureTag(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2020, time.November, 2, 12, 0, 0, 0, time.UTC)
+
 			r := &repo{
 				ui:    ui.NewNop(),
+				now:   func() time.Time { return now },
 				owner: tc.owner,
 				repo:  tc.repo,
 			}
@@ -405,7 +474,7 @@ func TestRepo_FutureTag(t *testing.T) {
 			tag := r.FutureTag(tc.tagName)
 
 			assert.NotEmpty(t, tag)
-			assert.NotZero(t, tag.Time)
+			assert.Equal(t, now, tag.Time)
 			assert.Equal(t, tc.expectedTagName, tag.Name)
 			assert.Equal(t, tc.expectedTagURL, tag.WebURL)
 		})
@@ -694,8 +763,10 @@ func TestRepo_FetchTags(t *testing.T) {
 		name          string
 		owner         string
 		repo          string
+		annotatedTags bool
 		commitsStore  *store
 		repoService   *MockRepoService
+		gitService    *MockGitService
 		ctx           context.Context
 		expectedTags  remote.Tags
 		expectedError string
@@ -766,9 +837,10 @@ func TestRepo_FetchTags(t *testing.T) {
 			expectedError: "error on getting github commits",
 		},
 		{
-			name:  "Success",
-			owner: "octocat",
-			repo:  "Hello-World",
+			name:          "Success",
+			owner:         "octocat",
+			repo:          "Hello-World",
+			annotatedTags: true,
 			commitsStore: &store{
 				m: map[interface{}]interface{}{},
 			},
@@ -791,21 +863,195 @@ func TestRepo_FetchTags(t *testing.T) {
 					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
 				},
 			},
+			gitService: &MockGitService{
+				TagRefsMocks: []TagRefsMock{
+					{
+						OutRefs: []gitRef{},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+			},
+			ctx:          context.Background(),
+			expectedTags: remote.Tags{remoteTag},
+		},
+		{
+			name:          "TagRefsFails_FirstPage",
+			owner:         "octocat",
+			repo:          "Hello-World",
+			annotatedTags: true,
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			repoService: &MockRepoService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: []github.Tag{gitHubTag},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
+				},
+			},
+			gitService: &MockGitService{
+				TagRefsMocks: []TagRefsMock{
+					{OutError: errors.New("error on getting github tag references")},
+				},
+			},
+			ctx:           context.Background(),
+			expectedError: "error on getting github tag references",
+		},
+		{
+			name:          "TagRefsFails_SecondPage",
+			owner:         "octocat",
+			repo:          "Hello-World",
+			annotatedTags: true,
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			repoService: &MockRepoService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: []github.Tag{gitHubTag},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
+				},
+			},
+			gitService: &MockGitService{
+				TagRefsMocks: []TagRefsMock{
+					{
+						OutRefs: []gitRef{},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 0, Prev: 0, Next: 2, Last: 2},
+						},
+					},
+					{OutError: errors.New("error on getting github tag references")},
+				},
+			},
+			ctx:           context.Background(),
+			expectedError: "error on getting github tag references",
+		},
+		{
+			name:          "TagFails",
+			owner:         "octocat",
+			repo:          "Hello-World",
+			annotatedTags: true,
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			repoService: &MockRepoService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: []github.Tag{gitHubTag},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
+				},
+			},
+			gitService: &MockGitService{
+				TagRefsMocks: []TagRefsMock{
+					{
+						OutRefs: []gitRef{gitHubTagRef},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				TagMocks: []TagMock{
+					{OutError: errors.New("error on getting github tag")},
+				},
+			},
+			ctx:           context.Background(),
+			expectedError: "error on getting github tag",
+		},
+		{
+			name:  "Success_WithoutAnnotatedTags",
+			owner: "octocat",
+			repo:  "Hello-World",
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			repoService: &MockRepoService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: []github.Tag{gitHubTag},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
+				},
+			},
+			gitService:   &MockGitService{},
 			ctx:          context.Background(),
 			expectedTags: remote.Tags{remoteTag},
 		},
+		{
+			name:          "Success_AnnotatedTag",
+			owner:         "octocat",
+			repo:          "Hello-World",
+			annotatedTags: true,
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			repoService: &MockRepoService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: []github.Tag{gitHubTag},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit2, OutResponse: &github.Response{}},
+				},
+			},
+			gitService: &MockGitService{
+				TagRefsMocks: []TagRefsMock{
+					{
+						OutRefs: []gitRef{gitHubTagRef},
+						OutResponse: &github.Response{
+							Pages: github.Pages{},
+						},
+					},
+				},
+				TagMocks: []TagMock{
+					{OutTag: &gitHubAnnotatedTag, OutResponse: &github.Response{}},
+				},
+			},
+			ctx:          context.Background(),
+			expectedTags: remote.Tags{remoteAnnotatedTag},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			r := &repo{
-				ui:    ui.NewNop(),
-				owner: tc.owner,
-				repo:  tc.repo,
+				ui:            ui.NewNop(),
+				owner:         tc.owner,
+				repo:          tc.repo,
+				annotatedTags: tc.annotatedTags,
 			}
 
 			r.stores.commits = tc.commitsStore
 			r.services.repo = tc.repoService
+			r.services.git = tc.gitService
 
 			tags, err := r.FetchTags(tc.ctx)
 
@@ -830,6 +1076,8 @@ func TestRepo_FetchIssuesAndMerges(t *testing.T) {
 		usersService   *MockUsersService
 		repoService    *MockRepoService
 		issueService   *MockIssueService
+		pullService    *MockPullService
+		mergeCommits   bool
 		ctx            context.Context
 		since          time.Time
 		expectedIssues remote.Issues
@@ -950,6 +1198,51 @@ func TestRepo_FetchIssuesAndMerges(t *testing.T) {
 			since:         since,
 			expectedError: "error on getting github commit",
 		},
+		{
+			name: "PullCommitsFail",
+			usersStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			usersService: &MockUsersService{},
+			repoService: &MockRepoService{
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit1, OutResponse: &github.Response{}},
+				},
+			},
+			issueService: &MockIssueService{
+				ListMocks: []IssuesListMock{
+					{
+						OutIssues: []github.Issue{gitHubIssue1},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 0, Prev: 0, Next: 2, Last: 2},
+						},
+					},
+					{
+						OutIssues: []github.Issue{gitHubIssue2},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 1, Prev: 1, Next: 0, Last: 0},
+						},
+					},
+				},
+				EventsMocks: []EventsMock{
+					// TODO: In lack of proper mocking, we need to return both events, so the findEvent method will not fail
+					{OutEvents: []github.Event{gitHubEvent1, gitHubEvent2}, OutResponse: &github.Response{}},
+					{OutEvents: []github.Event{gitHubEvent2, gitHubEvent1}, OutResponse: &github.Response{}},
+				},
+			},
+			pullService: &MockPullService{
+				CommitsMocks: []PullCommitsMock{
+					{OutError: errors.New("error on getting github pull commits")},
+				},
+			},
+			mergeCommits:  true,
+			ctx:           context.Background(),
+			since:         since,
+			expectedError: "error on getting github pull commits",
+		},
 		{
 			name: "UserFails_Author",
 			usersStore: &store{
@@ -1083,16 +1376,67 @@ func TestRepo_FetchIssuesAndMerges(t *testing.T) {
 			expectedIssues: remote.Issues{remoteIssue},
 			expectedMerges: remote.Merges{remoteMerge},
 		},
+		{
+			name: "Success_MergeCommits",
+			usersStore: &store{
+				m: map[interface{}]interface{}{
+					"octocat": gitHubUser1,
+					"octodog": gitHubUser2,
+					"octofox": gitHubUser3,
+				},
+			},
+			commitsStore: &store{
+				m: map[interface{}]interface{}{},
+			},
+			usersService: &MockUsersService{},
+			repoService: &MockRepoService{
+				CommitMocks: []CommitMock{
+					{OutCommit: &gitHubCommit1, OutResponse: &github.Response{}},
+				},
+			},
+			issueService: &MockIssueService{
+				ListMocks: []IssuesListMock{
+					{
+						OutIssues: []github.Issue{gitHubIssue1},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 0, Prev: 0, Next: 2, Last: 2},
+						},
+					},
+					{
+						OutIssues: []github.Issue{gitHubIssue2},
+						OutResponse: &github.Response{
+							Pages: github.Pages{First: 1, Prev: 1, Next: 0, Last: 0},
+						},
+					},
+				},
+				EventsMocks: []EventsMock{
+					// TODO: In lack of proper mocking, we need to return both events, so the findEvent method will not fail
+					{OutEvents: []github.Event{gitHubEvent1, gitHubEvent2}, OutResponse: &github.Response{}},
+					{OutEvents: []github.Event{gitHubEvent2, gitHubEvent1}, OutResponse: &github.Response{}},
+				},
+			},
+			pullService: &MockPullService{
+				CommitsMocks: []PullCommitsMock{
+					{OutCommits: []github.Commit{gitHubCommit1}, OutResponse: &github.Response{}},
+				},
+			},
+			mergeCommits:   true,
+			ctx:            context.Background(),
+			since:          since,
+			expectedIssues: remote.Issues{remoteIssue},
+			expectedMerges: remote.Merges{remoteMergeWithCommits},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			r := &repo{ui: ui.NewNop()}
+			r := &repo{ui: ui.NewNop(), mergeCommits: tc.mergeCommits}
 			r.stores.users = tc.usersStore
 			r.stores.commits = tc.commitsStore
 			r.services.users = tc.usersService
 			r.services.repo = tc.repoService
 			r.services.issues = tc.issueService
+			r.services.pulls = tc.pullService
 
 			issues, merges, err := r.FetchIssuesAndMerges(tc.ctx, tc.since)
 
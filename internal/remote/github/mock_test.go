@@ -7,7 +7,7 @@ import (
 
 	"github.com/gardenbed/go-github"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 var (
@@ -136,6 +136,25 @@ var (
 		},
 	}
 
+	gitHubTagRef = gitRef{
+		Ref: "refs/tags/v0.1.0",
+		Object: gitObject{
+			Type: "tag",
+			SHA:  "940bd336248efae0f9ee5bc7b2d5c985887b16ac",
+		},
+	}
+
+	gitHubAnnotatedTag = gitTag{
+		Tag:     "v0.1.0",
+		SHA:     "940bd336248efae0f9ee5bc7b2d5c985887b16ac",
+		Message: "The first release.\n",
+		Tagger: gitTagger{
+			Name:  "The Octocat",
+			Email: "octocat@github.com",
+			Date:  parseGitHubTime("2020-10-28T10:00:00Z"),
+		},
+	}
+
 	gitHubIssue1 = github.Issue{
 		ID:     1,
 		Number: 1001,
@@ -257,13 +276,26 @@ var (
 	}
 
 	remoteCommit1 = remote.Commit{
-		Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
-		Time: parseGitHubTime("2020-10-20T19:59:59Z"),
+		Hash:    "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Time:    parseGitHubTime("2020-10-20T19:59:59Z"),
+		Message: "Fix all the bugs",
+		Author: remote.User{
+			Name:     "The Octocat",
+			Email:    "octocat@github.com",
+			Username: "octocat",
+		},
 	}
 
 	remoteCommit2 = remote.Commit{
-		Hash: "c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c",
-		Time: parseGitHubTime("2020-10-27T23:59:59Z"),
+		Hash:    "c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c",
+		Time:    parseGitHubTime("2020-10-27T23:59:59Z"),
+		Message: "Release v0.1.0",
+		Author: remote.User{
+			Name:     "The Octocat",
+			Email:    "octocat@github.com",
+			Username: "octocat",
+		},
+		Parents: []string{"6dcb09b5b57875f334f61aebed695e2e4193db5e"},
 	}
 
 	remoteBranch = remote.Branch{
@@ -278,13 +310,28 @@ var (
 		WebURL: "https://github.com/octocat/Hello-World/tree/v0.1.0",
 	}
 
+	remoteAnnotatedTag = remote.Tag{
+		Name:    "v0.1.0",
+		Time:    parseGitHubTime("2020-10-27T23:59:59Z"),
+		Commit:  remoteCommit2,
+		WebURL:  "https://github.com/octocat/Hello-World/tree/v0.1.0",
+		Message: "The first release.",
+		Tagger: remote.User{
+			Name:  "The Octocat",
+			Email: "octocat@github.com",
+		},
+		TaggedAt: parseGitHubTime("2020-10-28T10:00:00Z"),
+	}
+
 	remoteIssue = remote.Issue{
 		Change: remote.Change{
 			Number:    1001,
 			Title:     "Found a bug",
+			Body:      "This is not working as expected!",
 			Labels:    []string{"bug"},
 			Milestone: "v1.0",
 			Time:      time.Time{},
+			CreatedAt: parseGitHubTime("2020-10-10T10:00:00Z"),
 			Author:    remoteUser1,
 			WebURL:    "https://github.com/octocat/Hello-World/issues/1001",
 		},
@@ -295,15 +342,24 @@ var (
 		Change: remote.Change{
 			Number:    1002,
 			Title:     "Fixed a bug",
+			Body:      "I made this to work as expected!",
 			Labels:    []string{"bug"},
 			Milestone: "v1.0",
 			Time:      parseGitHubTime("2020-10-20T19:59:59Z"),
+			CreatedAt: parseGitHubTime("2020-10-15T15:00:00Z"),
 			Author:    remoteUser2,
 			WebURL:    "https://github.com/octocat/Hello-World/pull/1002",
 		},
 		Merger: remoteUser3,
 		Commit: remoteCommit1,
 	}
+
+	remoteMergeWithCommits = remote.Merge{
+		Change:  remoteMerge.Change,
+		Merger:  remoteUser3,
+		Commit:  remoteCommit1,
+		Commits: remote.Commits{remoteCommit1},
+	}
 )
 
 func parseGitHubTime(s string) time.Time {
@@ -522,3 +578,82 @@ func (m *MockIssueService) Events(ctx context.Context, number, pageSize, pageNo
 	m.EventsMocks[i].InPageNo = pageNo
 	return m.EventsMocks[i].OutEvents, m.EventsMocks[i].OutResponse, m.EventsMocks[i].OutError
 }
+
+type (
+	TagRefsMock struct {
+		InContext   context.Context
+		InPageSize  int
+		InPageNo    int
+		OutRefs     []gitRef
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	TagMock struct {
+		InContext   context.Context
+		InSHA       string
+		OutTag      *gitTag
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	MockGitService struct {
+		TagRefsIndex int
+		TagRefsMocks []TagRefsMock
+
+		TagMutex sync.Mutex
+		TagIndex int
+		TagMocks []TagMock
+	}
+)
+
+func (m *MockGitService) TagRefs(ctx context.Context, pageSize, pageNo int) ([]gitRef, *github.Response, error) {
+	i := m.TagRefsIndex
+	m.TagRefsIndex++
+	m.TagRefsMocks[i].InContext = ctx
+	m.TagRefsMocks[i].InPageSize = pageSize
+	m.TagRefsMocks[i].InPageNo = pageNo
+	return m.TagRefsMocks[i].OutRefs, m.TagRefsMocks[i].OutResponse, m.TagRefsMocks[i].OutError
+}
+
+func (m *MockGitService) Tag(ctx context.Context, sha string) (*gitTag, *github.Response, error) {
+	m.TagMutex.Lock()
+	defer m.TagMutex.Unlock()
+
+	i := m.TagIndex
+	m.TagIndex++
+	m.TagMocks[i].InContext = ctx
+	m.TagMocks[i].InSHA = sha
+	return m.TagMocks[i].OutTag, m.TagMocks[i].OutResponse, m.TagMocks[i].OutError
+}
+
+type (
+	PullCommitsMock struct {
+		InContext   context.Context
+		InNumber    int
+		InPageSize  int
+		InPageNo    int
+		OutCommits  []github.Commit
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	MockPullService struct {
+		CommitsMutex sync.Mutex
+		CommitsIndex int
+		CommitsMocks []PullCommitsMock
+	}
+)
+
+func (m *MockPullService) Commits(ctx context.Context, number, pageSize, pageNo int) ([]github.Commit, *github.Response, error) {
+	m.CommitsMutex.Lock()
+	defer m.CommitsMutex.Unlock()
+
+	i := m.CommitsIndex
+	m.CommitsIndex++
+	m.CommitsMocks[i].InContext = ctx
+	m.CommitsMocks[i].InNumber = number
+	m.CommitsMocks[i].InPageSize = pageSize
+	m.CommitsMocks[i].InPageNo = pageNo
+	return m.CommitsMocks[i].OutCommits, m.CommitsMocks[i].OutResponse, m.CommitsMocks[i].OutError
+}
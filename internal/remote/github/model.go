@@ -2,11 +2,12 @@ package github
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gardenbed/go-github"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 func toUser(u github.User) remote.User {
@@ -19,9 +20,24 @@ func toUser(u github.User) remote.User {
 }
 
 func toCommit(c github.Commit) remote.Commit {
+	var parents []string
+	for _, p := range c.Parents {
+		parents = append(parents, p.SHA)
+	}
+
+	// c.Author is only set if the commit author is a GitHub user
 	return remote.Commit{
-		Hash: c.SHA,
-		Time: c.Commit.Committer.Time,
+		Hash:    c.SHA,
+		Time:    c.Commit.Committer.Time,
+		Message: c.Commit.Message,
+		Author: remote.User{
+			Name:     c.Commit.Author.Name,
+			Email:    c.Commit.Author.Email,
+			Username: c.Author.Login,
+			WebURL:   c.Author.HTMLURL,
+		},
+		WebURL:  c.HTMLURL,
+		Parents: parents,
 	}
 }
 
@@ -58,17 +74,28 @@ func toIssue(i github.Issue, e github.Event, author, closer github.User) remote.
 		time = *i.ClosedAt
 	}
 
+	// e.CommitID is only set if the issue is closed by a commit
+	var commit remote.Commit
+	if e.CommitID != "" {
+		commit = remote.Commit{
+			Hash: e.CommitID,
+		}
+	}
+
 	return remote.Issue{
 		Change: remote.Change{
 			Number:    i.Number,
 			Title:     i.Title,
+			Body:      i.Body,
 			Labels:    labels,
 			Milestone: milestone,
 			Time:      time,
+			CreatedAt: i.CreatedAt,
 			Author:    toUser(author),
 			WebURL:    i.HTMLURL,
 		},
 		Closer: toUser(closer),
+		Commit: commit,
 	}
 }
 
@@ -91,9 +118,11 @@ func toMerge(i github.Issue, e github.Event, c github.Commit, author, merger git
 		Change: remote.Change{
 			Number:    i.Number,
 			Title:     i.Title,
+			Body:      i.Body,
 			Labels:    labels,
 			Milestone: milestone,
 			Time:      time,
+			CreatedAt: i.CreatedAt,
 			Author:    toUser(author),
 			WebURL:    i.HTMLURL,
 		},
@@ -102,7 +131,7 @@ func toMerge(i github.Issue, e github.Event, c github.Commit, author, merger git
 	}
 }
 
-func resolveTags(gitHubTags, gitHubCommits *store, owner, repo string) remote.Tags {
+func resolveTags(gitHubTags, gitHubCommits, gitHubAnnotations *store, owner, repo string) remote.Tags {
 	tags := remote.Tags{}
 
 	_ = gitHubTags.ForEach(func(k, v interface{}) error {
@@ -110,7 +139,20 @@ func resolveTags(gitHubTags, gitHubCommits *store, owner, repo string) remote.Ta
 
 		if v, ok := gitHubCommits.Load(t.Commit.SHA); ok {
 			c := v.(github.Commit)
-			tags = append(tags, toTag(t, c, owner, repo))
+			tag := toTag(t, c, owner, repo)
+
+			// Only annotated tags have tag objects
+			if v, ok := gitHubAnnotations.Load(t.Name); ok {
+				a := v.(gitTag)
+				tag.Message = strings.TrimSpace(a.Message)
+				tag.Tagger = remote.User{
+					Name:  a.Tagger.Name,
+					Email: a.Tagger.Email,
+				}
+				tag.TaggedAt = a.Tagger.Date
+			}
+
+			tags = append(tags, tag)
 		}
 
 		return nil
@@ -119,7 +161,7 @@ func resolveTags(gitHubTags, gitHubCommits *store, owner, repo string) remote.Ta
 	return tags
 }
 
-func resolveIssuesAndMerges(gitHubIssues, gitHubEvents, gitHubCommits, gitHubUsers *store) (remote.Issues, remote.Merges) {
+func resolveIssuesAndMerges(gitHubIssues, gitHubEvents, gitHubPullCommits, gitHubCommits, gitHubUsers *store) (remote.Issues, remote.Merges) {
 	issues := remote.Issues{}
 	merges := remote.Merges{}
 
@@ -152,7 +194,16 @@ func resolveIssuesAndMerges(gitHubIssues, gitHubEvents, gitHubCommits, gitHubUse
 				v, _ = gitHubUsers.Load(e.Actor.Login)
 				merger := v.(github.User)
 
-				merges = append(merges, toMerge(i, e, c, author, merger))
+				m := toMerge(i, e, c, author, merger)
+
+				// The commits of pull requests are only available if they are fetched
+				if v, ok := gitHubPullCommits.Load(num); ok {
+					for _, pc := range v.([]github.Commit) {
+						m.Commits = append(m.Commits, toCommit(pc))
+					}
+				}
+
+				merges = append(merges, m)
 			}
 		}
 
@@ -6,7 +6,7 @@ import (
 	"github.com/gardenbed/go-github"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 func TestToUser(t *testing.T) {
@@ -114,6 +114,20 @@ func TestToIssue(t *testing.T) {
 			closer:        gitHubUser1,
 			expectedIssue: remoteIssue,
 		},
+		{
+			name:   "ClosedByCommit",
+			i:      gitHubIssue1,
+			e:      github.Event{Event: "closed", CommitID: "6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+			author: gitHubUser1,
+			closer: gitHubUser1,
+			expectedIssue: remote.Issue{
+				Change: remoteIssue.Change,
+				Closer: remoteUser1,
+				Commit: remote.Commit{
+					Hash: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -154,11 +168,12 @@ func TestToMerge(t *testing.T) {
 
 func TestResolveTags(t *testing.T) {
 	tests := []struct {
-		name          string
-		gitHubTags    *store
-		gitHubCommits *store
-		owner, repo   string
-		expectedTags  remote.Tags
+		name              string
+		gitHubTags        *store
+		gitHubCommits     *store
+		gitHubAnnotations *store
+		owner, repo       string
+		expectedTags      remote.Tags
 	}{
 		{
 			name: "OK",
@@ -172,15 +187,39 @@ func TestResolveTags(t *testing.T) {
 					"c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c": gitHubCommit2,
 				},
 			},
+			gitHubAnnotations: &store{
+				m: map[interface{}]interface{}{},
+			},
 			owner:        "octocat",
 			repo:         "Hello-World",
 			expectedTags: remote.Tags{remoteTag},
 		},
+		{
+			name: "Annotated",
+			gitHubTags: &store{
+				m: map[interface{}]interface{}{
+					"v0.1.0": gitHubTag,
+				},
+			},
+			gitHubCommits: &store{
+				m: map[interface{}]interface{}{
+					"c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c": gitHubCommit2,
+				},
+			},
+			gitHubAnnotations: &store{
+				m: map[interface{}]interface{}{
+					"v0.1.0": gitHubAnnotatedTag,
+				},
+			},
+			owner:        "octocat",
+			repo:         "Hello-World",
+			expectedTags: remote.Tags{remoteAnnotatedTag},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tags := resolveTags(tc.gitHubTags, tc.gitHubCommits, tc.owner, tc.repo)
+			tags := resolveTags(tc.gitHubTags, tc.gitHubCommits, tc.gitHubAnnotations, tc.owner, tc.repo)
 			assert.Equal(t, tc.expectedTags, tags)
 		})
 	}
@@ -188,13 +227,14 @@ func TestResolveTags(t *testing.T) {
 
 func TestResolveIssuesAndMerges(t *testing.T) {
 	tests := []struct {
-		name           string
-		gitHubIssues   *store
-		gitHubEvents   *store
-		gitHubCommits  *store
-		gitHubUsers    *store
-		expectedIssues remote.Issues
-		expectedMerges remote.Merges
+		name              string
+		gitHubIssues      *store
+		gitHubEvents      *store
+		gitHubPullCommits *store
+		gitHubCommits     *store
+		gitHubUsers       *store
+		expectedIssues    remote.Issues
+		expectedMerges    remote.Merges
 	}{
 		{
 			name: "OK",
@@ -210,6 +250,9 @@ func TestResolveIssuesAndMerges(t *testing.T) {
 					1002: gitHubEvent2,
 				},
 			},
+			gitHubPullCommits: &store{
+				m: map[interface{}]interface{}{},
+			},
 			gitHubCommits: &store{
 				m: map[interface{}]interface{}{
 					"6dcb09b5b57875f334f61aebed695e2e4193db5e": gitHubCommit1,
@@ -225,11 +268,42 @@ func TestResolveIssuesAndMerges(t *testing.T) {
 			expectedIssues: remote.Issues{remoteIssue},
 			expectedMerges: remote.Merges{remoteMerge},
 		},
+		{
+			name: "WithPullCommits",
+			gitHubIssues: &store{
+				m: map[interface{}]interface{}{
+					1002: gitHubIssue2,
+				},
+			},
+			gitHubEvents: &store{
+				m: map[interface{}]interface{}{
+					1002: gitHubEvent2,
+				},
+			},
+			gitHubPullCommits: &store{
+				m: map[interface{}]interface{}{
+					1002: []github.Commit{gitHubCommit1},
+				},
+			},
+			gitHubCommits: &store{
+				m: map[interface{}]interface{}{
+					"6dcb09b5b57875f334f61aebed695e2e4193db5e": gitHubCommit1,
+				},
+			},
+			gitHubUsers: &store{
+				m: map[interface{}]interface{}{
+					"octodog": gitHubUser2,
+					"octofox": gitHubUser3,
+				},
+			},
+			expectedIssues: remote.Issues{},
+			expectedMerges: remote.Merges{remoteMergeWithCommits},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			issues, merges := resolveIssuesAndMerges(tc.gitHubIssues, tc.gitHubEvents, tc.gitHubCommits, tc.gitHubUsers)
+			issues, merges := resolveIssuesAndMerges(tc.gitHubIssues, tc.gitHubEvents, tc.gitHubPullCommits, tc.gitHubCommits, tc.gitHubUsers)
 
 			assert.Equal(t, tc.expectedIssues, issues)
 			assert.Equal(t, tc.expectedMerges, merges)
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardenbed/go-github"
+)
+
+// pullDataService provides access to the commits of GitHub pull requests.
+// This API is not covered by the github.PullService.
+type pullDataService struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+// Commits retrieves the commits of a pull request.
+// See https://docs.github.com/en/rest/pulls/pulls#list-commits-on-a-pull-request
+func (s *pullDataService) Commits(ctx context.Context, number, pageSize, pageNo int) ([]github.Commit, *github.Response, error) {
+	url := fmt.Sprintf("/repos/%s/%s/pulls/%d/commits", s.owner, s.repo, number)
+	req, err := s.client.NewPageRequest(ctx, "GET", url, pageSize, pageNo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commits := []github.Commit{}
+
+	resp, err := s.client.Do(req, &commits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commits, resp, nil
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+)
+
+const gitHubPullCommitsBody = `[
+	{
+		"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		"commit": {
+			"message": "Fix all the bugs",
+			"author": { "name": "The Octocat", "email": "octocat@github.com", "date": "2020-10-20T19:59:59Z" },
+			"committer": { "name": "The Octocat", "email": "octocat@github.com", "date": "2020-10-20T19:59:59Z" }
+		},
+		"author": { "id": 1, "login": "octocat", "type": "User" },
+		"committer": { "id": 1, "login": "octocat", "type": "User" }
+	}
+]`
+
+func newTestPullDataService(t *testing.T, handler http.HandlerFunc) (*pullDataService, func()) {
+	ts := httptest.NewServer(handler)
+
+	client, err := github.NewEnterpriseClient(ts.URL, ts.URL, ts.URL, "")
+	assert.NoError(t, err)
+
+	s := &pullDataService{
+		client: client,
+		owner:  "octocat",
+		repo:   "Hello-World",
+	}
+
+	return s, ts.Close
+}
+
+func TestPullDataService_Commits(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		body            string
+		expectedCommits []github.Commit
+		expectedError   bool
+	}{
+		{
+			name:          "Fails",
+			statusCode:    http.StatusNotFound,
+			body:          `{ "message": "Not Found" }`,
+			expectedError: true,
+		},
+		{
+			name:            "Success",
+			statusCode:      http.StatusOK,
+			body:            gitHubPullCommitsBody,
+			expectedCommits: []github.Commit{gitHubCommit1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, close := newTestPullDataService(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/octocat/Hello-World/pulls/1002/commits", r.URL.Path)
+				assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+				assert.Equal(t, "1", r.URL.Query().Get("page"))
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			})
+			defer close()
+
+			commits, resp, err := s.Commits(context.Background(), 1002, 100, 1)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, commits)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+				assert.Equal(t, tc.expectedCommits, commits)
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 
 	"github.com/gardenbed/charm/ui"
 
-	"github.com/gardenbed/changelog/internal/remote"
+	"github.com/gardenbed/changelog/remote"
 )
 
 // repo implements the remote.Repo interface for GitLab.
@@ -20,10 +20,13 @@ type repo struct {
 }
 
 // NewRepo creates a new GitLab repository.
-func NewRepo(ui ui.UI, path, accessToken string) remote.Repo {
-	transport := &http.Transport{}
-	client := &http.Client{
-		Transport: transport,
+// A new HTTP client is created if the given client is nil.
+func NewRepo(ui ui.UI, path, accessToken string, client *http.Client) remote.Repo {
+	if client == nil {
+		transport := &http.Transport{}
+		client = &http.Client{
+			Transport: transport,
+		}
 	}
 
 	return &repo{
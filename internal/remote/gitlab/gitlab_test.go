@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ func TestNewRepo(t *testing.T) {
 		ui          ui.UI
 		path        string
 		accessToken string
+		client      *http.Client
 	}{
 		{
 			name:        "OK",
@@ -22,11 +24,18 @@ func TestNewRepo(t *testing.T) {
 			path:        "gardenbed/changelog",
 			accessToken: "gitlab-access-token",
 		},
+		{
+			name:        "WithHTTPClient",
+			ui:          ui.New(ui.Info),
+			path:        "gardenbed/changelog",
+			accessToken: "gitlab-access-token",
+			client:      &http.Client{},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			r := NewRepo(tc.ui, tc.path, tc.accessToken)
+			r := NewRepo(tc.ui, tc.path, tc.accessToken, tc.client)
 			assert.NotNil(t, r)
 
 			gr, ok := r.(*repo)
@@ -34,6 +43,9 @@ func TestNewRepo(t *testing.T) {
 
 			assert.Equal(t, tc.ui, gr.ui)
 			assert.NotNil(t, gr.client)
+			if tc.client != nil {
+				assert.Same(t, tc.client, gr.client)
+			}
 			assert.Equal(t, tc.path, gr.path)
 			assert.Equal(t, tc.accessToken, gr.accessToken)
 		})
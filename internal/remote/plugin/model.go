@@ -0,0 +1,246 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+type (
+	// request is the message written to the standard input of a provider.
+	request struct {
+		Protocol int         `json:"protocol"`
+		Method   string      `json:"method"`
+		Repo     repoInfo    `json:"repo"`
+		Params   interface{} `json:"params"`
+	}
+
+	// repoInfo is the remote repository that a request is made for.
+	repoInfo struct {
+		Path        string `json:"path"`
+		AccessToken string `json:"access_token"`
+	}
+
+	// response is the message read from the standard output of a provider.
+	response struct {
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
+	}
+)
+
+type (
+	nameParams struct {
+		Name string `json:"name"`
+	}
+
+	compareParams struct {
+		Base string `json:"base"`
+		Head string `json:"head"`
+	}
+
+	sinceParams struct {
+		Since time.Time `json:"since"`
+	}
+
+	refParams struct {
+		Ref string `json:"ref"`
+	}
+
+	urlResult struct {
+		URL string `json:"url"`
+	}
+
+	issuesAndMergesResult struct {
+		Issues []issue `json:"issues"`
+		Merges []merge `json:"merges"`
+	}
+)
+
+type (
+	user struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+		WebURL   string `json:"web_url"`
+	}
+
+	commit struct {
+		Hash    string    `json:"hash"`
+		Time    time.Time `json:"time"`
+		Message string    `json:"message"`
+		Author  user      `json:"author"`
+		WebURL  string    `json:"web_url"`
+		Parents []string  `json:"parents"`
+	}
+
+	branch struct {
+		Name   string `json:"name"`
+		Commit commit `json:"commit"`
+	}
+
+	tag struct {
+		Name     string    `json:"name"`
+		Time     time.Time `json:"time"`
+		Commit   commit    `json:"commit"`
+		WebURL   string    `json:"web_url"`
+		Message  string    `json:"message"`
+		Tagger   user      `json:"tagger"`
+		TaggedAt time.Time `json:"tagged_at"`
+	}
+
+	change struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		Body      string    `json:"body"`
+		Labels    []string  `json:"labels"`
+		Milestone string    `json:"milestone"`
+		Time      time.Time `json:"time"`
+		CreatedAt time.Time `json:"created_at"`
+		Author    user      `json:"author"`
+		WebURL    string    `json:"web_url"`
+	}
+
+	issue struct {
+		change
+		Closer user    `json:"closer"`
+		Commit commit  `json:"commit"`
+		Merges []merge `json:"merges"`
+	}
+
+	merge struct {
+		change
+		Merger  user     `json:"merger"`
+		Commit  commit   `json:"commit"`
+		Commits []commit `json:"commits"`
+	}
+)
+
+func (c commit) validate() error {
+	if c.Hash == "" {
+		return errors.New("commit hash is required")
+	}
+	return nil
+}
+
+func (b branch) validate() error {
+	if b.Name == "" {
+		return errors.New("branch name is required")
+	}
+	return b.Commit.validate()
+}
+
+func (t tag) validate() error {
+	if t.Name == "" {
+		return errors.New("tag name is required")
+	}
+	return t.Commit.validate()
+}
+
+func (c change) validate() error {
+	if c.Number <= 0 {
+		return errors.New("change number is required")
+	}
+	if c.Title == "" {
+		return errors.New("change title is required")
+	}
+	return nil
+}
+
+func (i issue) validate() error {
+	return i.change.validate()
+}
+
+func (m merge) validate() error {
+	if err := m.change.validate(); err != nil {
+		return err
+	}
+	for _, c := range m.Commits {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+	return m.Commit.validate()
+}
+
+func toUser(u user) remote.User {
+	return remote.User{
+		Name:     u.Name,
+		Email:    u.Email,
+		Username: u.Username,
+		WebURL:   u.WebURL,
+	}
+}
+
+func toCommit(c commit) remote.Commit {
+	return remote.Commit{
+		Hash:    c.Hash,
+		Time:    c.Time,
+		Message: c.Message,
+		Author:  toUser(c.Author),
+		WebURL:  c.WebURL,
+		Parents: c.Parents,
+	}
+}
+
+func toBranch(b branch) remote.Branch {
+	return remote.Branch{
+		Name:   b.Name,
+		Commit: toCommit(b.Commit),
+	}
+}
+
+func toTag(t tag) remote.Tag {
+	return remote.Tag{
+		Name:     t.Name,
+		Time:     t.Time,
+		Commit:   toCommit(t.Commit),
+		WebURL:   t.WebURL,
+		Message:  t.Message,
+		Tagger:   toUser(t.Tagger),
+		TaggedAt: t.TaggedAt,
+	}
+}
+
+func toChange(c change) remote.Change {
+	return remote.Change{
+		Number:    c.Number,
+		Title:     c.Title,
+		Body:      c.Body,
+		Labels:    c.Labels,
+		Milestone: c.Milestone,
+		Time:      c.Time,
+		CreatedAt: c.CreatedAt,
+		Author:    toUser(c.Author),
+		WebURL:    c.WebURL,
+	}
+}
+
+func toIssue(i issue) remote.Issue {
+	var merges remote.Merges
+	for _, m := range i.Merges {
+		merges = append(merges, toMerge(m))
+	}
+
+	return remote.Issue{
+		Change: toChange(i.change),
+		Closer: toUser(i.Closer),
+		Commit: toCommit(i.Commit),
+		Merges: merges,
+	}
+}
+
+func toMerge(m merge) remote.Merge {
+	var commits remote.Commits
+	for _, c := range m.Commits {
+		commits = append(commits, toCommit(c))
+	}
+
+	return remote.Merge{
+		Change:  toChange(m.change),
+		Merger:  toUser(m.Merger),
+		Commit:  toCommit(m.Commit),
+		Commits: commits,
+	}
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+func TestToIssue(t *testing.T) {
+	tests := []struct {
+		name          string
+		i             issue
+		expectedIssue remote.Issue
+	}{
+		{
+			name: "WithMerges",
+			i: issue{
+				change: change{
+					Number: 1001,
+					Title:  "Found a bug",
+					Labels: []string{"bug"},
+				},
+				Closer: user{Username: "octocat"},
+				Merges: []merge{
+					{
+						change:  change{Number: 1002, Title: "Fixed a bug"},
+						Commit:  commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"},
+						Commits: []commit{{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"}},
+					},
+				},
+			},
+			expectedIssue: remote.Issue{
+				Change: remote.Change{
+					Number: 1001,
+					Title:  "Found a bug",
+					Labels: []string{"bug"},
+				},
+				Closer: remote.User{Username: "octocat"},
+				Merges: remote.Merges{
+					{
+						Change:  remote.Change{Number: 1002, Title: "Fixed a bug"},
+						Commit:  remote.Commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"},
+						Commits: remote.Commits{{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := toIssue(tc.i)
+			assert.Equal(t, tc.expectedIssue, issue)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		v             interface{ validate() error }
+		expectedError string
+	}{
+		{
+			name:          "IssueWithoutNumber",
+			v:             issue{change: change{Title: "Found a bug"}},
+			expectedError: "change number is required",
+		},
+		{
+			name:          "IssueWithoutTitle",
+			v:             issue{change: change{Number: 1001}},
+			expectedError: "change title is required",
+		},
+		{
+			name:          "TagWithoutCommit",
+			v:             tag{Name: "v0.1.0"},
+			expectedError: "commit hash is required",
+		},
+		{
+			name: "MergeCommitWithoutHash",
+			v: merge{
+				change:  change{Number: 1002, Title: "Fixed a bug"},
+				Commit:  commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"},
+				Commits: []commit{{Message: "Fix the bug"}},
+			},
+			expectedError: "commit hash is required",
+		},
+		{
+			name: "ValidMerge",
+			v: merge{
+				change: change{Number: 1002, Title: "Fixed a bug"},
+				Commit: commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.v.validate()
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
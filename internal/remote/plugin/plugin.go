@@ -0,0 +1,250 @@
+// Package plugin provides functionality to interact with remote repositories through external provider executables.
+//
+// A provider is an executable named changelog-provider-<name> available on the PATH.
+// For every call, the provider is spawned and a single JSON request is written to its standard input.
+// The provider writes a single JSON response to its standard output and exits.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+const (
+	// ExecutablePrefix is the prefix for the names of provider executables.
+	ExecutablePrefix = "changelog-provider-"
+
+	// ProtocolVersion is the version of the protocol spoken with provider executables.
+	ProtocolVersion = 1
+)
+
+// repo implements the remote.Repo interface for external providers.
+type repo struct {
+	ui          ui.UI
+	name        string
+	executable  string
+	path        string
+	accessToken string
+}
+
+// NewRepo creates a new repository backed by the changelog-provider-<name> executable.
+// An error is returned if the executable cannot be found on the PATH.
+func NewRepo(ui ui.UI, name, path, accessToken string) (remote.Repo, error) {
+	if name == "" {
+		return nil, errors.New("provider name is required")
+	}
+
+	executable, err := exec.LookPath(ExecutablePrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s not found: %s", name, err)
+	}
+
+	return &repo{
+		ui:          ui,
+		name:        name,
+		executable:  executable,
+		path:        path,
+		accessToken: accessToken,
+	}, nil
+}
+
+// call spawns the provider executable, sends a request for a method, and decodes the result of the response.
+func (r *repo) call(ctx context.Context, method string, params, result interface{}) error {
+	if params == nil {
+		params = struct{}{}
+	}
+
+	in, err := json.Marshal(request{
+		Protocol: ProtocolVersion,
+		Method:   method,
+		Repo: repoInfo{
+			Path:        r.path,
+			AccessToken: r.accessToken,
+		},
+		Params: params,
+	})
+	if err != nil {
+		return fmt.Errorf("provider %s: %s: %s", r.name, method, err)
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, r.executable)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("provider %s: %s: %s: %s", r.name, method, err, msg)
+		}
+		return fmt.Errorf("provider %s: %s: %s", r.name, method, err)
+	}
+
+	resp := new(response)
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return r.invalid(method, err)
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("provider %s: %s: %s", r.name, method, resp.Error)
+	}
+
+	if result != nil {
+		if len(resp.Result) == 0 {
+			return r.invalid(method, errors.New("no result"))
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return r.invalid(method, err)
+		}
+	}
+
+	return nil
+}
+
+// invalid returns an error for a response that does not pass the validation.
+func (r *repo) invalid(method string, err error) error {
+	return fmt.Errorf("provider %s: %s: invalid response: %s", r.name, method, err)
+}
+
+// FutureTag returns a tag that does not exist yet using an external provider.
+// The provider errors are reported as warnings and a zero tag is returned.
+func (r *repo) FutureTag(name string) remote.Tag {
+	var t tag
+	if err := r.call(context.Background(), "FutureTag", nameParams{Name: name}, &t); err != nil {
+		r.ui.Warnf(ui.Yellow, "%s", err)
+		return remote.Tag{}
+	}
+
+	return toTag(t)
+}
+
+// CompareURL returns a URL for comparing two revisions using an external provider.
+// The provider errors are reported as warnings and an empty URL is returned.
+func (r *repo) CompareURL(base, head string) string {
+	var res urlResult
+	if err := r.call(context.Background(), "CompareURL", compareParams{Base: base, Head: head}, &res); err != nil {
+		r.ui.Warnf(ui.Yellow, "%s", err)
+		return ""
+	}
+
+	return res.URL
+}
+
+// CheckPermissions ensures the client has all the required permissions using an external provider.
+func (r *repo) CheckPermissions(ctx context.Context) error {
+	return r.call(ctx, "CheckPermissions", nil, nil)
+}
+
+// FetchFirstCommit retrieves the firist/initial commit using an external provider.
+func (r *repo) FetchFirstCommit(ctx context.Context) (remote.Commit, error) {
+	var c commit
+	if err := r.call(ctx, "FetchFirstCommit", nil, &c); err != nil {
+		return remote.Commit{}, err
+	}
+
+	if err := c.validate(); err != nil {
+		return remote.Commit{}, r.invalid("FetchFirstCommit", err)
+	}
+
+	return toCommit(c), nil
+}
+
+// FetchBranch retrieves a branch by name using an external provider.
+func (r *repo) FetchBranch(ctx context.Context, name string) (remote.Branch, error) {
+	var b branch
+	if err := r.call(ctx, "FetchBranch", nameParams{Name: name}, &b); err != nil {
+		return remote.Branch{}, err
+	}
+
+	if err := b.validate(); err != nil {
+		return remote.Branch{}, r.invalid("FetchBranch", err)
+	}
+
+	return toBranch(b), nil
+}
+
+// FetchDefaultBranch retrieves the default branch using an external provider.
+func (r *repo) FetchDefaultBranch(ctx context.Context) (remote.Branch, error) {
+	var b branch
+	if err := r.call(ctx, "FetchDefaultBranch", nil, &b); err != nil {
+		return remote.Branch{}, err
+	}
+
+	if err := b.validate(); err != nil {
+		return remote.Branch{}, r.invalid("FetchDefaultBranch", err)
+	}
+
+	return toBranch(b), nil
+}
+
+// FetchTags retrieves all tags using an external provider.
+func (r *repo) FetchTags(ctx context.Context) (remote.Tags, error) {
+	var ts []tag
+	if err := r.call(ctx, "FetchTags", nil, &ts); err != nil {
+		return nil, err
+	}
+
+	tags := remote.Tags{}
+	for _, t := range ts {
+		if err := t.validate(); err != nil {
+			return nil, r.invalid("FetchTags", err)
+		}
+		tags = append(tags, toTag(t))
+	}
+
+	return tags, nil
+}
+
+// FetchIssuesAndMerges retrieves all closed issues and merged pull/merge requests using an external provider.
+func (r *repo) FetchIssuesAndMerges(ctx context.Context, since time.Time) (remote.Issues, remote.Merges, error) {
+	var res issuesAndMergesResult
+	if err := r.call(ctx, "FetchIssuesAndMerges", sinceParams{Since: since}, &res); err != nil {
+		return nil, nil, err
+	}
+
+	issues := remote.Issues{}
+	for _, i := range res.Issues {
+		if err := i.validate(); err != nil {
+			return nil, nil, r.invalid("FetchIssuesAndMerges", err)
+		}
+		issues = append(issues, toIssue(i))
+	}
+
+	merges := remote.Merges{}
+	for _, m := range res.Merges {
+		if err := m.validate(); err != nil {
+			return nil, nil, r.invalid("FetchIssuesAndMerges", err)
+		}
+		merges = append(merges, toMerge(m))
+	}
+
+	return issues, merges, nil
+}
+
+// FetchParentCommits retrieves all parent commits of a given commit hash using an external provider.
+func (r *repo) FetchParentCommits(ctx context.Context, ref string) (remote.Commits, error) {
+	var cs []commit
+	if err := r.call(ctx, "FetchParentCommits", refParams{Ref: ref}, &cs); err != nil {
+		return nil, err
+	}
+
+	commits := remote.Commits{}
+	for _, c := range cs {
+		if err := c.validate(); err != nil {
+			return nil, r.invalid("FetchParentCommits", err)
+		}
+		commits = append(commits, toCommit(c))
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,484 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/changelog/remote"
+)
+
+// providerDir is the directory containing the fake provider executable built for tests.
+var providerDir string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "changelog-provider-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, ExecutablePrefix+"fake"), "./testdata/provider")
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(dir)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	providerDir = dir
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func newFakeRepo(t *testing.T, mode, accessToken string) *repo {
+	t.Setenv("PATH", providerDir)
+	t.Setenv("FAKE_PROVIDER_MODE", mode)
+
+	r, err := NewRepo(ui.NewNop(), "fake", "octocat/Hello-World", accessToken)
+	assert.NoError(t, err)
+
+	return r.(*repo)
+}
+
+var fakeCommit = remote.Commit{
+	Hash:    "c414d1004154c6c324bd78c69d10ee101e676059",
+	Time:    time.Date(2020, time.October, 2, 2, 0, 0, 0, time.UTC),
+	Message: "Add feature",
+	Author: remote.User{
+		Name:     "The Octocat",
+		Email:    "octocat@example.com",
+		Username: "octocat",
+	},
+	Parents: []string{"20c5414eccaa147f2d6644de4ca36f35293fa43e"},
+}
+
+func TestNewRepo(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		providerName  string
+		expectedError string
+	}{
+		{
+			name:          "NoName",
+			path:          providerDir,
+			providerName:  "",
+			expectedError: "provider name is required",
+		},
+		{
+			name:          "NotFound",
+			path:          providerDir,
+			providerName:  "jira",
+			expectedError: `provider jira not found: exec: "changelog-provider-jira": executable file not found in $PATH`,
+		},
+		{
+			name:         "OK",
+			path:         providerDir,
+			providerName: "fake",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("PATH", tc.path)
+
+			r, err := NewRepo(ui.NewNop(), tc.providerName, "octocat/Hello-World", "access-token")
+
+			if tc.expectedError != "" {
+				assert.Nil(t, r)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+
+				pr, ok := r.(*repo)
+				assert.True(t, ok)
+
+				assert.Equal(t, tc.providerName, pr.name)
+				assert.Equal(t, filepath.Join(providerDir, ExecutablePrefix+tc.providerName), pr.executable)
+				assert.Equal(t, "octocat/Hello-World", pr.path)
+				assert.Equal(t, "access-token", pr.accessToken)
+			}
+		})
+	}
+}
+
+func TestRepo_FutureTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		tagName     string
+		expectedTag remote.Tag
+	}{
+		{
+			name:        "Error",
+			mode:        "error",
+			tagName:     "v0.1.0",
+			expectedTag: remote.Tag{},
+		},
+		{
+			name:    "Success",
+			tagName: "v0.1.0",
+			expectedTag: remote.Tag{
+				Name:   "v0.1.0",
+				WebURL: "https://example.com/octocat/Hello-World/tags/v0.1.0",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			tag := r.FutureTag(tc.tagName)
+
+			assert.Equal(t, tc.expectedTag, tag)
+		})
+	}
+}
+
+func TestRepo_CompareURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		base, head  string
+		expectedURL string
+	}{
+		{
+			name:        "Error",
+			mode:        "garbage",
+			base:        "v0.1.0",
+			head:        "v0.2.0",
+			expectedURL: "",
+		},
+		{
+			name:        "Success",
+			base:        "v0.1.0",
+			head:        "v0.2.0",
+			expectedURL: "https://example.com/octocat/Hello-World/compare/v0.1.0...v0.2.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			url := r.CompareURL(tc.base, tc.head)
+
+			assert.Equal(t, tc.expectedURL, url)
+		})
+	}
+}
+
+func TestRepo_CheckPermissions(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		accessToken   string
+		expectedError string
+	}{
+		{
+			name:          "ProcessFails",
+			mode:          "exit",
+			accessToken:   "access-token",
+			expectedError: "provider fake: CheckPermissions: exit status 2: something went wrong",
+		},
+		{
+			name:          "ProviderError",
+			accessToken:   "",
+			expectedError: "provider fake: CheckPermissions: access token is required",
+		},
+		{
+			name:        "Success",
+			accessToken: "access-token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, tc.accessToken)
+			err := r.CheckPermissions(context.Background())
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchFirstCommit(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		expectedCommit remote.Commit
+		expectedError  string
+	}{
+		{
+			name:          "InvalidJSON",
+			mode:          "garbage",
+			expectedError: "provider fake: FetchFirstCommit: invalid response: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:          "InvalidCommit",
+			mode:          "invalid",
+			expectedError: "provider fake: FetchFirstCommit: invalid response: commit hash is required",
+		},
+		{
+			name:           "Success",
+			expectedCommit: fakeCommit,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			commit, err := r.FetchFirstCommit(context.Background())
+
+			if tc.expectedError != "" {
+				assert.Empty(t, commit)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCommit, commit)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		branchName     string
+		expectedBranch remote.Branch
+		expectedError  string
+	}{
+		{
+			name:          "ProviderError",
+			mode:          "error",
+			branchName:    "main",
+			expectedError: "provider fake: FetchBranch: request failed",
+		},
+		{
+			name:          "InvalidBranch",
+			mode:          "invalid",
+			branchName:    "main",
+			expectedError: "provider fake: FetchBranch: invalid response: branch name is required",
+		},
+		{
+			name:       "Success",
+			branchName: "release",
+			expectedBranch: remote.Branch{
+				Name:   "release",
+				Commit: fakeCommit,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			branch, err := r.FetchBranch(context.Background(), tc.branchName)
+
+			if tc.expectedError != "" {
+				assert.Empty(t, branch)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBranch, branch)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		expectedBranch remote.Branch
+		expectedError  string
+	}{
+		{
+			name:          "ProviderError",
+			mode:          "error",
+			expectedError: "provider fake: FetchDefaultBranch: request failed",
+		},
+		{
+			name: "Success",
+			expectedBranch: remote.Branch{
+				Name:   "main",
+				Commit: fakeCommit,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			branch, err := r.FetchDefaultBranch(context.Background())
+
+			if tc.expectedError != "" {
+				assert.Empty(t, branch)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBranch, branch)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		expectedTags  remote.Tags
+		expectedError string
+	}{
+		{
+			name:          "ProcessFails",
+			mode:          "exit",
+			expectedError: "provider fake: FetchTags: exit status 2: something went wrong",
+		},
+		{
+			name:          "InvalidTag",
+			mode:          "invalid",
+			expectedError: "provider fake: FetchTags: invalid response: tag name is required",
+		},
+		{
+			name: "Success",
+			expectedTags: remote.Tags{
+				{
+					Name:   "v0.1.0",
+					Time:   time.Date(2020, time.October, 2, 2, 0, 0, 0, time.UTC),
+					Commit: fakeCommit,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			tags, err := r.FetchTags(context.Background())
+
+			if tc.expectedError != "" {
+				assert.Nil(t, tags)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTags, tags)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchIssuesAndMerges(t *testing.T) {
+	since := time.Date(2020, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mode           string
+		expectedIssues remote.Issues
+		expectedMerges remote.Merges
+		expectedError  string
+	}{
+		{
+			name:          "ProviderError",
+			mode:          "error",
+			expectedError: "provider fake: FetchIssuesAndMerges: request failed",
+		},
+		{
+			name:          "InvalidMerge",
+			mode:          "invalid",
+			expectedError: "provider fake: FetchIssuesAndMerges: invalid response: commit hash is required",
+		},
+		{
+			name: "Success",
+			expectedIssues: remote.Issues{
+				{
+					Change: remote.Change{
+						Number: 1001,
+						Title:  "Found a bug",
+						Labels: []string{"bug"},
+						Time:   since,
+					},
+				},
+			},
+			expectedMerges: remote.Merges{
+				{
+					Change: remote.Change{
+						Number:    1002,
+						Title:     "Fixed a bug",
+						Labels:    []string{"bug"},
+						CreatedAt: time.Date(2020, time.October, 1, 1, 0, 0, 0, time.UTC),
+					},
+					Commit:  fakeCommit,
+					Commits: remote.Commits{fakeCommit},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			issues, merges, err := r.FetchIssuesAndMerges(context.Background(), since)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, issues)
+				assert.Nil(t, merges)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedIssues, issues)
+				assert.Equal(t, tc.expectedMerges, merges)
+			}
+		})
+	}
+}
+
+func TestRepo_FetchParentCommits(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            string
+		ref             string
+		expectedCommits remote.Commits
+		expectedError   string
+	}{
+		{
+			name:          "InvalidCommit",
+			mode:          "invalid",
+			ref:           "main",
+			expectedError: "provider fake: FetchParentCommits: invalid response: commit hash is required",
+		},
+		{
+			name:            "Success",
+			ref:             "main",
+			expectedCommits: remote.Commits{fakeCommit},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRepo(t, tc.mode, "access-token")
+			commits, err := r.FetchParentCommits(context.Background(), tc.ref)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, commits)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCommits, commits)
+			}
+		})
+	}
+}
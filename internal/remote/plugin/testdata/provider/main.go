@@ -0,0 +1,118 @@
+// Command changelog-provider-fake is a fake provider used for testing the provider protocol.
+// The FAKE_PROVIDER_MODE environment variable controls how the provider responds.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type request struct {
+	Protocol int    `json:"protocol"`
+	Method   string `json:"method"`
+	Repo     struct {
+		Path        string `json:"path"`
+		AccessToken string `json:"access_token"`
+	} `json:"repo"`
+	Params map[string]interface{} `json:"params"`
+}
+
+type response struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+var commit = map[string]interface{}{
+	"hash":    "c414d1004154c6c324bd78c69d10ee101e676059",
+	"time":    "2020-10-02T02:00:00Z",
+	"message": "Add feature",
+	"author": map[string]interface{}{
+		"name":     "The Octocat",
+		"email":    "octocat@example.com",
+		"username": "octocat",
+	},
+	"parents": []string{"20c5414eccaa147f2d6644de4ca36f35293fa43e"},
+}
+
+func result(req request) (interface{}, string) {
+	switch req.Method {
+	case "FutureTag":
+		return map[string]interface{}{
+			"name":    req.Params["name"],
+			"web_url": fmt.Sprintf("https://example.com/%s/tags/%s", req.Repo.Path, req.Params["name"]),
+		}, ""
+	case "CompareURL":
+		return map[string]interface{}{
+			"url": fmt.Sprintf("https://example.com/%s/compare/%s...%s", req.Repo.Path, req.Params["base"], req.Params["head"]),
+		}, ""
+	case "CheckPermissions":
+		if req.Repo.AccessToken == "" {
+			return nil, "access token is required"
+		}
+		return nil, ""
+	case "FetchFirstCommit":
+		return commit, ""
+	case "FetchBranch":
+		return map[string]interface{}{"name": req.Params["name"], "commit": commit}, ""
+	case "FetchDefaultBranch":
+		return map[string]interface{}{"name": "main", "commit": commit}, ""
+	case "FetchTags":
+		return []interface{}{
+			map[string]interface{}{"name": "v0.1.0", "time": "2020-10-02T02:00:00Z", "commit": commit},
+		}, ""
+	case "FetchIssuesAndMerges":
+		return map[string]interface{}{
+			"issues": []interface{}{
+				map[string]interface{}{"number": 1001, "title": "Found a bug", "labels": []string{"bug"}, "time": req.Params["since"]},
+			},
+			"merges": []interface{}{
+				map[string]interface{}{"number": 1002, "title": "Fixed a bug", "labels": []string{"bug"}, "created_at": "2020-10-01T01:00:00Z", "commit": commit, "commits": []interface{}{commit}},
+			},
+		}, ""
+	case "FetchParentCommits":
+		return []interface{}{commit}, ""
+	default:
+		return nil, fmt.Sprintf("unknown method: %s", req.Method)
+	}
+}
+
+func main() {
+	var req request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if req.Protocol != 1 {
+		fmt.Fprintf(os.Stderr, "unsupported protocol: %d\n", req.Protocol)
+		os.Exit(1)
+	}
+
+	var resp response
+
+	switch os.Getenv("FAKE_PROVIDER_MODE") {
+	case "exit":
+		fmt.Fprintln(os.Stderr, "something went wrong")
+		os.Exit(2)
+	case "garbage":
+		fmt.Fprint(os.Stdout, "not json")
+		return
+	case "error":
+		resp.Error = "request failed"
+	case "invalid":
+		resp.Result = []interface{}{map[string]interface{}{}}
+		if req.Method == "FetchIssuesAndMerges" {
+			resp.Result = map[string]interface{}{"merges": []interface{}{map[string]interface{}{"number": 1002, "title": "Fixed a bug"}}}
+		} else if req.Method != "FetchTags" && req.Method != "FetchParentCommits" {
+			resp.Result = map[string]interface{}{}
+		}
+	default:
+		resp.Result, resp.Error = result(req)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,137 @@
+// Package semver provides functionality for parsing and comparing tag names as semantic versions.
+// See https://semver.org for more information.
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// Version is a semantic version.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Metadata   string
+}
+
+// Parse parses a tag name as a semantic version.
+// The tag name can have an optional v prefix (i.e. v2.0.0-rc.1).
+func Parse(name string) (Version, bool) {
+	sm := semverRegex.FindStringSubmatch(name)
+	if len(sm) != 6 {
+		return Version{}, false
+	}
+
+	// The numbers are already validated by the regex
+	major, _ := strconv.Atoi(sm[1])
+	minor, _ := strconv.Atoi(sm[2])
+	patch, _ := strconv.Atoi(sm[3])
+
+	return Version{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: sm[4],
+		Metadata:   sm[5],
+	}, true
+}
+
+// IsPrerelease determines whether or not a version is a pre-release version.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Core returns the version without the pre-release and build metadata.
+func (v Version) Core() Version {
+	return Version{
+		Major: v.Major,
+		Minor: v.Minor,
+		Patch: v.Patch,
+	}
+}
+
+// Compare compares two versions according to the precedence rules.
+// The result is -1 if v < u, 0 if v == u, and +1 if v > u.
+// Build metadata is ignored when comparing versions.
+func (v Version) Compare(u Version) int {
+	if c := compareInt(v.Major, u.Major); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.Minor, u.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.Patch, u.Patch); c != 0 {
+		return c
+	}
+
+	// A pre-release version has a lower precedence than a normal version
+	switch {
+	case v.Prerelease == u.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case u.Prerelease == "":
+		return -1
+	}
+
+	vIDs := strings.Split(v.Prerelease, ".")
+	uIDs := strings.Split(u.Prerelease, ".")
+
+	for i := 0; i < len(vIDs) && i < len(uIDs); i++ {
+		if c := compareIdentifier(vIDs[i], uIDs[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A larger set of pre-release identifiers has a higher precedence
+	return compareInt(len(vIDs), len(uIDs))
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Metadata != "" {
+		s += "+" + v.Metadata
+	}
+
+	return s
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIdentifier compares two pre-release identifiers.
+// Numeric identifiers are compared numerically and have a lower precedence than alphanumeric identifiers.
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInt(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
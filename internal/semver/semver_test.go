@@ -0,0 +1,96 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name            string
+		tagName         string
+		expectedOK      bool
+		expectedVersion Version
+	}{
+		{
+			name:       "Invalid",
+			tagName:    "release-1",
+			expectedOK: false,
+		},
+		{
+			name:       "LeadingZero",
+			tagName:    "v1.02.0",
+			expectedOK: false,
+		},
+		{
+			name:            "Release",
+			tagName:         "v2.0.0",
+			expectedOK:      true,
+			expectedVersion: Version{Major: 2, Minor: 0, Patch: 0},
+		},
+		{
+			name:            "WithoutPrefix",
+			tagName:         "0.1.10",
+			expectedOK:      true,
+			expectedVersion: Version{Major: 0, Minor: 1, Patch: 10},
+		},
+		{
+			name:            "Prerelease",
+			tagName:         "v2.0.0-rc.1+build.7",
+			expectedOK:      true,
+			expectedVersion: Version{Major: 2, Minor: 0, Patch: 0, Prerelease: "rc.1", Metadata: "build.7"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, ok := Parse(tc.tagName)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedVersion, v)
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// Sorted by precedence as in the SemVer specification
+	sorted := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := range sorted {
+		for j := range sorted {
+			v, _ := Parse(sorted[i])
+			u, _ := Parse(sorted[j])
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			assert.Equal(t, expected, v.Compare(u), "%s vs. %s", sorted[i], sorted[j])
+		}
+	}
+}
+
+func TestVersion_Core(t *testing.T) {
+	v, _ := Parse("v2.0.0-rc.1+build.7")
+
+	assert.True(t, v.IsPrerelease())
+	assert.False(t, v.Core().IsPrerelease())
+	assert.Equal(t, "2.0.0", v.Core().String())
+	assert.Equal(t, "2.0.0-rc.1+build.7", v.String())
+}
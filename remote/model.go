@@ -5,10 +5,26 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// closingRegex matches the keywords for closing an issue from a pull/merge request description.
+// See https://docs.github.com/en/issues/tracking-your-work-with-issues/linking-a-pull-request-to-an-issue
+var closingRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// coAuthorRegex matches the Co-authored-by trailers in a commit message.
+// See https://docs.github.com/en/pull-requests/committing-changes-to-your-project/creating-and-editing-commits/creating-a-commit-with-multiple-authors
+var coAuthorRegex = regexp.MustCompile(`(?im)^co-authored-by:\s*(.+?)\s*<([^>]+)>\s*$`)
+
+// sourceBranchRegex matches the source branch in the default merge commit messages of GitHub and GitLab.
+var sourceBranchRegex = regexp.MustCompile(`^Merge (?:pull request #\d+ from [^/\s]+/(\S+)|branch '([^']+)' into )`)
+
+// releaseNoteRegex matches a fenced release-note block in a pull/merge request description.
+// See https://github.com/kubernetes/community/blob/master/contributors/guide/release-notes.md
+var releaseNoteRegex = regexp.MustCompile("(?s)```release-note[ \t]*\r?\n(.*?)```")
+
 // User represents a user.
 type User struct {
 	Name     string
@@ -17,15 +33,53 @@ type User struct {
 	WebURL   string
 }
 
+// IsBot determines if a user is a bot account (i.e. renovate[bot]).
+func (u User) IsBot() bool {
+	return strings.HasSuffix(u.Username, "[bot]")
+}
+
 // Commit represents a commit.
+// Parents are the hashes of the parent commits and the first parent is the previous commit on the same branch.
 type Commit struct {
-	Hash string
-	Time time.Time
+	Hash    string
+	Time    time.Time
+	Message string
+	Author  User
+	WebURL  string
+	Parents []string
 }
 
 // IsZero determines if a commit is a zero commit instance.
 func (c Commit) IsZero() bool {
-	return c == Commit{}
+	return reflect.ValueOf(c).IsZero()
+}
+
+// IsMerge determines if a commit is a merge commit (a commit with more than one parent).
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Title returns the first line of the commit message.
+func (c Commit) Title() string {
+	title := strings.TrimSpace(c.Message)
+	if i := strings.IndexByte(title, '\n'); i >= 0 {
+		title = title[:i]
+	}
+
+	return strings.TrimSpace(title)
+}
+
+// CoAuthors returns the co-authors of a commit specified by Co-authored-by trailers in the commit message.
+func (c Commit) CoAuthors() []User {
+	users := []User{}
+	for _, sm := range coAuthorRegex.FindAllStringSubmatch(c.Message, -1) {
+		users = append(users, User{
+			Name:  sm[1],
+			Email: sm[2],
+		})
+	}
+
+	return users
 }
 
 func (c Commit) String() string {
@@ -67,11 +121,21 @@ func (b Branch) String() string {
 }
 
 // Tag represents a tag.
+// Time is the time of the commit that the tag refers to.
+// Message, Tagger, and TaggedAt are only set for annotated tags.
 type Tag struct {
-	Name   string
-	Time   time.Time
-	Commit Commit
-	WebURL string
+	Name     string
+	Time     time.Time
+	Commit   Commit
+	WebURL   string
+	Message  string
+	Tagger   User
+	TaggedAt time.Time
+}
+
+// IsAnnotated determines if a tag is an annotated tag.
+func (t Tag) IsAnnotated() bool {
+	return !t.TaggedAt.IsZero()
 }
 
 // IsZero determines if a tag is a zero tag instance.
@@ -269,21 +333,60 @@ func (l Labels) String() string {
 	return strings.Join(l, ",")
 }
 
+// scopes returns a sorted list of all labels with the given prefix with the prefix removed.
+func scopes(prefix string, labels []Labels) []string {
+	mp := map[string]bool{}
+	for _, l := range labels {
+		for _, label := range l {
+			if strings.HasPrefix(label, prefix) && len(label) > len(prefix) {
+				mp[strings.TrimPrefix(label, prefix)] = true
+			}
+		}
+	}
+
+	scopes := []string{}
+	for scope := range mp {
+		scopes = append(scopes, scope)
+	}
+
+	sort.Strings(scopes)
+
+	return scopes
+}
+
+// Ticket represents an issue in an external issue tracker (i.e. Jira) referenced by a change.
+type Ticket struct {
+	Key         string
+	Type        string
+	Summary     string
+	FixVersions []string
+	WebURL      string
+}
+
 // Change has the common fields of an issue or a merge/pull request.
+// Time is the time the issue is closed or the merge/pull request is merged and CreatedAt is the time it is opened.
+// Tickets are the issues in external issue trackers referenced by the change.
 type Change struct {
 	Number    int
 	Title     string
+	Body      string
 	Labels    Labels
 	Milestone string
 	Time      time.Time
+	CreatedAt time.Time
 	Author    User
 	WebURL    string
+	Tickets   []Ticket
 }
 
 // Issue represents an issue.
+// Commit is the commit that closed the issue if any.
+// Merges are the pull/merge requests that closed the issue if any.
 type Issue struct {
 	Change
 	Closer User
+	Commit Commit
+	Merges Merges
 }
 
 // Issues is a collection of issues.
@@ -337,11 +440,84 @@ func (i Issues) Milestones() []string {
 	return milestones
 }
 
+// Scopes returns a sorted list of all scopes in the collection of issues.
+// Scopes are the labels with the given prefix with the prefix removed.
+func (i Issues) Scopes(prefix string) []string {
+	labels := []Labels{}
+	for _, issue := range i {
+		labels = append(labels, issue.Labels)
+	}
+
+	return scopes(prefix, labels)
+}
+
 // Merge represents a merge/pull request.
+// Commit is the commit that merged the merge/pull request (a merge, squash, or the last rebased commit).
+// Commits are the commits of the merge/pull request if they are fetched.
 type Merge struct {
 	Change
-	Merger User
-	Commit Commit
+	Merger  User
+	Commit  Commit
+	Commits Commits
+}
+
+// ClosedIssues returns the numbers of issues closed by a merge using a keyword (i.e. Closes #1001).
+func (m Merge) ClosedIssues() []int {
+	nums := []int{}
+	for _, sm := range closingRegex.FindAllStringSubmatch(m.Body, -1) {
+		// The regex guarantees a number
+		num, _ := strconv.Atoi(sm[1])
+		nums = append(nums, num)
+	}
+
+	return nums
+}
+
+// ReleaseNote returns the text of the release-note block in the description of a pull/merge request if any.
+// The whitespaces in the text are collapsed, so it can be used as a single line.
+func (m Merge) ReleaseNote() (string, bool) {
+	sm := releaseNoteRegex.FindStringSubmatch(m.Body)
+	if len(sm) != 2 {
+		return "", false
+	}
+
+	note := strings.Join(strings.Fields(sm[1]), " ")
+	if note == "" {
+		return "", false
+	}
+
+	return note, true
+}
+
+// SourceBranch returns the source branch of a pull/merge request from the message of its merge commit if any.
+// Squashed and rebased pull/merge requests do not have the source branch in their commit messages.
+func (m Merge) SourceBranch() string {
+	sm := sourceBranchRegex.FindStringSubmatch(m.Commit.Title())
+	if sm == nil {
+		return ""
+	}
+
+	if sm[1] != "" {
+		return sm[1]
+	}
+
+	return sm[2]
+}
+
+// Closes determines if a merge closes a given issue.
+// A merge closes an issue if the issue is closed by the merge commit or the merge body refers to the issue using a keyword.
+func (m Merge) Closes(i Issue) bool {
+	if !i.Commit.IsZero() && i.Commit.Hash == m.Commit.Hash {
+		return true
+	}
+
+	for _, num := range m.ClosedIssues() {
+		if num == i.Number {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Merges is a collection of merges.
@@ -378,6 +554,17 @@ func (m Merges) Select(f func(Merge) bool) (Merges, Merges) {
 	return selected, unselected
 }
 
+// Scopes returns a sorted list of all scopes in the collection of merges.
+// Scopes are the labels with the given prefix with the prefix removed.
+func (m Merges) Scopes(prefix string) []string {
+	labels := []Labels{}
+	for _, merge := range m {
+		labels = append(labels, merge.Labels)
+	}
+
+	return scopes(prefix, labels)
+}
+
 // Milestones returns a sorted list of all milestones in the collection of merges.
 func (m Merges) Milestones() []string {
 	mp := map[string]bool{}
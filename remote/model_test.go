@@ -51,10 +51,13 @@ var (
 	}
 
 	tag2 = Tag{
-		Name:   "v0.2.0",
-		Time:   t2,
-		Commit: commit2,
-		WebURL: "https://github.com/octocat/Hello-World/tree/v0.2.0",
+		Name:     "v0.2.0",
+		Time:     t2,
+		Commit:   commit2,
+		WebURL:   "https://github.com/octocat/Hello-World/tree/v0.2.0",
+		Message:  "Release v0.2.0",
+		Tagger:   User{Name: "The Octocat", Email: "octocat@github.com"},
+		TaggedAt: t2,
 	}
 
 	issue1 = Issue{
@@ -112,41 +115,121 @@ var (
 	}
 )
 
+func TestUser_IsBot(t *testing.T) {
+	tests := []struct {
+		name          string
+		u             User
+		expectedIsBot bool
+	}{
+		{
+			name:          "User",
+			u:             user1,
+			expectedIsBot: false,
+		},
+		{
+			name:          "Bot",
+			u:             User{Username: "renovate[bot]"},
+			expectedIsBot: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIsBot, tc.u.IsBot())
+		})
+	}
+}
+
 func TestCommit(t *testing.T) {
 	tests := []struct {
-		name           string
-		c              Commit
-		expectedIsZero bool
-		expectedString string
+		name            string
+		c               Commit
+		expectedIsZero  bool
+		expectedIsMerge bool
+		expectedTitle   string
+		expectedString  string
 	}{
 		{
-			name:           "Zero",
-			c:              Commit{},
-			expectedIsZero: true,
-			expectedString: "",
+			name:            "Zero",
+			c:               Commit{},
+			expectedIsZero:  true,
+			expectedIsMerge: false,
+			expectedTitle:   "",
+			expectedString:  "",
 		},
 		{
-			name:           "Commit1",
-			c:              commit1,
-			expectedIsZero: false,
-			expectedString: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+			name:            "Commit1",
+			c:               commit1,
+			expectedIsZero:  false,
+			expectedIsMerge: false,
+			expectedTitle:   "",
+			expectedString:  "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
 		},
 		{
-			name:           "Commit2",
-			c:              commit2,
-			expectedIsZero: false,
-			expectedString: "0251a422d2038967eeaaaa5c8aa76c7067fdef05",
+			name:            "Commit2",
+			c:               commit2,
+			expectedIsZero:  false,
+			expectedIsMerge: false,
+			expectedTitle:   "",
+			expectedString:  "0251a422d2038967eeaaaa5c8aa76c7067fdef05",
+		},
+		{
+			name: "MergeCommit",
+			c: Commit{
+				Hash:    "c414d1004154c6c324bd78c69d10ee101e676059",
+				Message: "Merge branch 'main' into release\n\nConflicts resolved.",
+				Parents: []string{"0251a422d2038967eeaaaa5c8aa76c7067fdef05", "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378"},
+			},
+			expectedIsZero:  false,
+			expectedIsMerge: true,
+			expectedTitle:   "Merge branch 'main' into release",
+			expectedString:  "c414d1004154c6c324bd78c69d10ee101e676059",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			assert.Equal(t, tc.expectedIsZero, tc.c.IsZero())
+			assert.Equal(t, tc.expectedIsMerge, tc.c.IsMerge())
+			assert.Equal(t, tc.expectedTitle, tc.c.Title())
 			assert.Equal(t, tc.expectedString, tc.c.String())
 		})
 	}
 }
 
+func TestCommit_CoAuthors(t *testing.T) {
+	tests := []struct {
+		name              string
+		c                 Commit
+		expectedCoAuthors []User
+	}{
+		{
+			name:              "NoCoAuthor",
+			c:                 commit1,
+			expectedCoAuthors: []User{},
+		},
+		{
+			name: "WithCoAuthors",
+			c: Commit{
+				Hash:    "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+				Message: "Fix a bug (#1001)\n\nCo-authored-by: The Octocat <octocat@github.com>\nco-authored-by: The Octodog <octodog@github.com>",
+			},
+			expectedCoAuthors: []User{
+				{Name: "The Octocat", Email: "octocat@github.com"},
+				{Name: "The Octodog", Email: "octodog@github.com"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			coAuthors := tc.c.CoAuthors()
+
+			assert.Equal(t, tc.expectedCoAuthors, coAuthors)
+		})
+	}
+}
+
 func TestCommits_Any(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -230,34 +313,39 @@ func TestBranch(t *testing.T) {
 
 func TestTag(t *testing.T) {
 	tests := []struct {
-		name           string
-		t              Tag
-		expectedIsZero bool
-		expectedString string
+		name                string
+		t                   Tag
+		expectedIsZero      bool
+		expectedIsAnnotated bool
+		expectedString      string
 	}{
 		{
-			name:           "Zero",
-			t:              Tag{},
-			expectedIsZero: true,
-			expectedString: "",
+			name:                "Zero",
+			t:                   Tag{},
+			expectedIsZero:      true,
+			expectedIsAnnotated: false,
+			expectedString:      "",
 		},
 		{
-			name:           "Tag1",
-			t:              tag1,
-			expectedIsZero: false,
-			expectedString: "v0.1.0 Commit[25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378]",
+			name:                "Tag1",
+			t:                   tag1,
+			expectedIsZero:      false,
+			expectedIsAnnotated: false,
+			expectedString:      "v0.1.0 Commit[25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378]",
 		},
 		{
-			name:           "Tag2",
-			t:              tag2,
-			expectedIsZero: false,
-			expectedString: "v0.2.0 Commit[0251a422d2038967eeaaaa5c8aa76c7067fdef05]",
+			name:                "Tag2",
+			t:                   tag2,
+			expectedIsZero:      false,
+			expectedIsAnnotated: true,
+			expectedString:      "v0.2.0 Commit[0251a422d2038967eeaaaa5c8aa76c7067fdef05]",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			assert.Equal(t, tc.expectedIsZero, tc.t.IsZero())
+			assert.Equal(t, tc.expectedIsAnnotated, tc.t.IsAnnotated())
 			assert.Equal(t, tc.expectedString, tc.t.String())
 		})
 	}
@@ -734,6 +822,42 @@ func TestIssues_Select(t *testing.T) {
 	}
 }
 
+func TestIssues_Scopes(t *testing.T) {
+	issue3 := issue1
+	issue3.Labels = Labels{"bug", "area/cli"}
+
+	issue4 := issue2
+	issue4.Labels = Labels{"area/api", "area/cli", "area/"}
+
+	tests := []struct {
+		name           string
+		i              Issues
+		prefix         string
+		expectedScopes []string
+	}{
+		{
+			name:           "NoScope",
+			i:              Issues{issue1, issue2},
+			prefix:         "area/",
+			expectedScopes: []string{},
+		},
+		{
+			name:           "OK",
+			i:              Issues{issue3, issue4},
+			prefix:         "area/",
+			expectedScopes: []string{"api", "cli"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scopes := tc.i.Scopes(tc.prefix)
+
+			assert.Equal(t, tc.expectedScopes, scopes)
+		})
+	}
+}
+
 func TestIssues_Milestones(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -756,6 +880,186 @@ func TestIssues_Milestones(t *testing.T) {
 	}
 }
 
+func TestMerge_ClosedIssues(t *testing.T) {
+	tests := []struct {
+		name         string
+		m            Merge
+		expectedNums []int
+	}{
+		{
+			name:         "NoBody",
+			m:            merge1,
+			expectedNums: []int{},
+		},
+		{
+			name: "WithKeywords",
+			m: Merge{
+				Change: Change{
+					Body: "Fixes #1001\nThis change also closes #1002 and resolves: #1003.\nSee #1004",
+				},
+			},
+			expectedNums: []int{1001, 1002, 1003},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nums := tc.m.ClosedIssues()
+
+			assert.Equal(t, tc.expectedNums, nums)
+		})
+	}
+}
+
+func TestMerge_ReleaseNote(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedNote string
+		expectedOK   bool
+	}{
+		{
+			name:         "NoBody",
+			body:         "",
+			expectedNote: "",
+			expectedOK:   false,
+		},
+		{
+			name:         "NoBlock",
+			body:         "This is a description.",
+			expectedNote: "",
+			expectedOK:   false,
+		},
+		{
+			name:         "ReleaseNotesBlock",
+			body:         "```release-notes\nThis is a release summary.\n```",
+			expectedNote: "",
+			expectedOK:   false,
+		},
+		{
+			name:         "BothBlocks",
+			body:         "```release-notes\nThis is a release summary.\n```\n\n```release-note\nAdded the -verbose flag.\n```",
+			expectedNote: "Added the -verbose flag.",
+			expectedOK:   true,
+		},
+		{
+			name:         "EmptyBlock",
+			body:         "```release-note\n\n```",
+			expectedNote: "",
+			expectedOK:   false,
+		},
+		{
+			name:         "NONE",
+			body:         "#### Does this PR introduce a user-facing change?\r\n```release-note\r\nNONE\r\n```\r\n",
+			expectedNote: "NONE",
+			expectedOK:   true,
+		},
+		{
+			name:         "MultiLine",
+			body:         "Some details.\n\n```release-note\nAdded the -verbose flag\nfor showing more logs.\n```\n",
+			expectedNote: "Added the -verbose flag for showing more logs.",
+			expectedOK:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := Merge{
+				Change: Change{
+					Body: tc.body,
+				},
+			}
+
+			note, ok := m.ReleaseNote()
+
+			assert.Equal(t, tc.expectedNote, note)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestMerge_SourceBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		expectedBranch string
+	}{
+		{
+			name:           "NoMessage",
+			message:        "",
+			expectedBranch: "",
+		},
+		{
+			name:           "SquashMerge",
+			message:        "PROJ-1234 Add login (#1002)",
+			expectedBranch: "",
+		},
+		{
+			name:           "GitHub",
+			message:        "Merge pull request #1002 from octodog/feature/PROJ-1234-login\n\nAdd login",
+			expectedBranch: "feature/PROJ-1234-login",
+		},
+		{
+			name:           "GitLab",
+			message:        "Merge branch 'PROJ-1234-login' into 'main'\n\nAdd login",
+			expectedBranch: "PROJ-1234-login",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := Merge{
+				Commit: Commit{
+					Message: tc.message,
+				},
+			}
+
+			assert.Equal(t, tc.expectedBranch, m.SourceBranch())
+		})
+	}
+}
+
+func TestMerge_Closes(t *testing.T) {
+	tests := []struct {
+		name           string
+		m              Merge
+		i              Issue
+		expectedCloses bool
+	}{
+		{
+			name:           "NotClosing",
+			m:              merge1,
+			i:              issue1,
+			expectedCloses: false,
+		},
+		{
+			name: "ClosedByCommit",
+			m:    merge1,
+			i: Issue{
+				Change: Change{Number: 1001},
+				Commit: Commit{Hash: commit1.Hash},
+			},
+			expectedCloses: true,
+		},
+		{
+			name: "ClosedByKeyword",
+			m: Merge{
+				Change: Change{Body: "Closes #1001"},
+			},
+			i:              issue1,
+			expectedCloses: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			closes := tc.m.Closes(tc.i)
+
+			assert.Equal(t, tc.expectedCloses, closes)
+		})
+	}
+}
+
 func TestMerges_Sort(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -819,6 +1123,42 @@ func TestMerges_Select(t *testing.T) {
 	}
 }
 
+func TestMerges_Scopes(t *testing.T) {
+	merge3 := merge1
+	merge3.Labels = Labels{"bug", "area/cli"}
+
+	merge4 := merge2
+	merge4.Labels = Labels{"area/api", "area/cli", "area/"}
+
+	tests := []struct {
+		name           string
+		m              Merges
+		prefix         string
+		expectedScopes []string
+	}{
+		{
+			name:           "NoScope",
+			m:              Merges{merge1, merge2},
+			prefix:         "area/",
+			expectedScopes: []string{},
+		},
+		{
+			name:           "OK",
+			m:              Merges{merge3, merge4},
+			prefix:         "area/",
+			expectedScopes: []string{"api", "cli"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scopes := tc.m.Scopes(tc.prefix)
+
+			assert.Equal(t, tc.expectedScopes, scopes)
+		})
+	}
+}
+
 func TestMerges_Milestones(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1,4 +1,5 @@
 // Package remote represent a remote repository abstraction.
+// Any implementation of the Repo interface can be used for generating changelogs.
 package remote
 
 import (
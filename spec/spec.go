@@ -3,21 +3,28 @@ package spec
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/fatih/color"
 	"gopkg.in/yaml.v3"
+
+	"github.com/gardenbed/changelog/internal/filter"
 )
 
 const envVarName = "CHANGELOG_ACCESS_TOKEN"
 
+const jiraEnvVarName = "CHANGELOG_JIRA_TOKEN"
+
 var specFiles = []string{"changelog.yml", "changelog.yaml"}
 
 const helpTemplate = `
   changelog is a simple command-line tool for generating changelogs based on issues and pull/merge requests.
-  It assumes the remote repository name is origin.
+  The remote repository is read from the origin git remote (or the only git remote) unless the remote option is set.
 
   You can also have a changelog.yaml file in your repository for configuring how changelogs are generated.
   For more information, please see https://github.com/gardenbed/changelog
@@ -26,7 +33,15 @@ const helpTemplate = `
 
     • GitHub (github.com)
 
-  Usage: changelog [flags]
+  Usage: changelog [command] [flags]
+
+  Commands:
+
+    lint                          Check the changelog file for problems instead of generating it
+                                  The exit status is 2 if any problem is found
+    audit                         Report the changes since the last release that would be dropped or uncategorized
+                                  The exit status is 2 if any change is reported
+    stats                         Print the statistics of the new releases instead of generating the changelog
 
   Flags:
 
@@ -36,23 +51,46 @@ const helpTemplate = `
     {{ yellow "-access-token                 The OAuth access token for making API calls" }}
     {{ yellow "                              The default value is read from the CHANGELOG_ACCESS_TOKEN environment variable" }}
 
+    -remote                       The name of the git remote for the remote repository (default: {{if .Repo.Remote}}{{.Repo.Remote}}{{else}}origin or the only git remote{{end}})
+    -platform                     The platform of the remote repository instead of reading the git remote (values: github.com|gitlab.com) {{if .Repo.Platform}}(default: {{.Repo.Platform}}){{end}}
+    -path                         The path of the remote repository instead of reading the git remote (i.e. octocat/Hello-World) {{if .Repo.Path}}(default: {{.Repo.Path}}){{end}}
+                                  The platform and path options are required together, unless an external provider is used
+
     -file                         The output file for the generated changelog (default: {{.General.File}})
     -base                         An optional file for appending the generated changelog to it {{if .General.Base}}(default: {{.General.Base}}){{end}}
                                   This option can only be used when generating the changelog for the first time
     -print                        Print the generated changelong to STDOUT (default: {{.General.Print}})
                                   If this option is enabled, all logs will be disabled
     -verbose                      Show the vervbosity logs (default: {{.General.Verbose}})
+    -json                         Print the output of commands in JSON format (default: {{.General.JSON}})
+    -provider                     An external provider for the remote repository instead of the built-in platforms {{if .General.Provider}}(default: {{.General.Provider}}){{end}}
+                                  The changelog-provider-<name> executable is looked up in the PATH
 
     -from-tag                     Changelog will be generated for all changes after this tag (default: last tag on changelog)
     -to-tag                       Changelog will be generated for all changes before this tag (default: last git tag)
     -future-tag                   A future tag for all unreleased changes (changes after the last git tag) {{if .Tags.Future}}(default: {{.Tags.Future ","}}){{end}}
+    -unreleased                   Maintain an Unreleased section for all changes after the last git tag on every run (default: {{.Tags.Unreleased}})
+                                  This option cannot be used with the future-tag option
+    -prerelease                   Handling of SemVer pre-release tags (values: separate|rollup) (default: {{.Tags.Prerelease}})
+                                  The rollup policy adds the changes of pre-releases (i.e. v2.0.0-rc.1) to their final release (i.e. v2.0.0)
+                                  or to the latest pre-release (i.e. v2.0.0-rc.2) until the final release is tagged
+    -prerelease-origin            List the pre-release that first shipped each rolled-up change (default: {{.Tags.PrereleaseOrigin}})
+    -tag-date                     Date of releases (values: commit|tag) (default: {{.Tags.Date}})
+                                  The tag date is only available for annotated tags
     -exclude-tags                 These tags will be excluded from changelog {{if .Tags.Exclude}}(default: {{Join .Tags.Exclude ","}}){{end}}
     -exclude-tags-regex           A POSIX-compliant regex for excluding certain tags from changelog {{if .Tags.ExcludeRegex}}(default: {{.Tags.ExcludeRegex}}){{end}}
 
     -issues-selection             Include closed issues in changelog (values: none|all|labeled) (default: {{.Issues.Selection}})
     -issues-include-labels        Include issues with these labels {{if .Issues.IncludeLabels}}(default: {{Join .Issues.IncludeLabels ","}}){{end}}
     -issues-exclude-labels        Exclude issues with these labels {{if .Issues.ExcludeLabels}}(default: {{Join .Issues.ExcludeLabels ","}}){{end}}
-    -issues-grouping              Grouping style for issues (values: simple|milestone|label) (default: {{.Issues.Grouping}})
+    -issues-include-authors       Include issues by these authors {{if .Issues.IncludeAuthors}}(default: {{Join .Issues.IncludeAuthors ","}}){{end}}
+    -issues-exclude-authors       Exclude issues by these authors {{if .Issues.ExcludeAuthors}}(default: {{Join .Issues.ExcludeAuthors ","}}){{end}}
+    -issues-exclude-authors-regex A POSIX-compliant regex for excluding issues by certain authors {{if .Issues.ExcludeAuthorsRegex}}(default: {{.Issues.ExcludeAuthorsRegex}}){{end}}
+    -issues-bots                  How to handle issues by bots (values: include|exclude|group|collapse) (default: {{.Issues.Bots}})
+    -issues-filter                An expression for filtering issues (e.g. 'label:bug && author!~"bot"') {{if .Issues.Filter}}(default: {{.Issues.Filter}}){{end}}
+    -issues-grouping              Grouping style for issues (values: simple|milestone|label|scope) (default: {{.Issues.Grouping}})
+                                  Groupings can be chained for nested groups (i.e. milestone>label)
+    -issues-scope-prefix          The label prefix for scope grouping (default: {{.Issues.ScopePrefix}})
     -issues-summary-labels        Labels for summary group {{if .Issues.SummaryLabels}}(default: {{Join .Issues.SummaryLabels ","}}){{end}}
     -issues-removed-labels        Labels for removed group {{if .Issues.RemovedLabels}}(default: {{Join .Issues.RemovedLabels ","}}){{end}}
     -issues-breaking-labels       Labels for breaking group {{if .Issues.BreakingLabels}}(default: {{Join .Issues.BreakingLabels ","}}){{end}}
@@ -61,12 +99,21 @@ const helpTemplate = `
     -issues-enhancement-labels    Labels for enhancement group {{if .Issues.EnhancementLabels}}(default: {{Join .Issues.EnhancementLabels ","}}){{end}}
     -issues-bug-labels            Labels for bug group {{if .Issues.BugLabels}}(default: {{Join .Issues.BugLabels ","}}){{end}}
     -issues-security-labels       Labels for security group {{if .Issues.SecurityLabels}}(default: {{Join .Issues.SecurityLabels ","}}){{end}}
+    -issues-replace-groups        Replace the default label groups with the custom groups defined in the spec file (default: {{.Issues.ReplaceGroups}})
 
     -merges-selection             Include merged pull/merge requests in changelog (values: none|all|labeled) (default: {{.Merges.Selection}})
     -merges-branch                Include pull/merge requests merged into this branch (default: default remote branch)
     -merges-include-labels        Include merges with these labels {{if .Merges.IncludeLabels}}(default: {{Join .Merges.IncludeLabels ","}}){{end}}
     -merges-exclude-labels        Exclude merges with these labels {{if .Merges.ExcludeLabels}}(default: {{Join .Merges.ExcludeLabels ","}}){{end}}
-    -merges-grouping              Grouping style for pull/merge requests (values: simple|milestone|label) (default: {{.Merges.Grouping}})
+    -merges-include-authors       Include merges by these authors {{if .Merges.IncludeAuthors}}(default: {{Join .Merges.IncludeAuthors ","}}){{end}}
+    -merges-exclude-authors       Exclude merges by these authors {{if .Merges.ExcludeAuthors}}(default: {{Join .Merges.ExcludeAuthors ","}}){{end}}
+    -merges-exclude-authors-regex A POSIX-compliant regex for excluding merges by certain authors {{if .Merges.ExcludeAuthorsRegex}}(default: {{.Merges.ExcludeAuthorsRegex}}){{end}}
+    -merges-bots                  How to handle merges by bots (values: include|exclude|group|collapse) (default: {{.Merges.Bots}})
+    -merges-filter                An expression for filtering merges (e.g. 'label:bug && author!~"bot"') {{if .Merges.Filter}}(default: {{.Merges.Filter}}){{end}}
+    -merges-release-notes         Use release-note blocks in descriptions instead of titles and skip the ones with NONE (default: {{.Merges.ReleaseNotes}})
+    -merges-grouping              Grouping style for pull/merge requests (values: simple|milestone|label|scope) (default: {{.Merges.Grouping}})
+                                  Groupings can be chained for nested groups (i.e. milestone>label)
+    -merges-scope-prefix          The label prefix for scope grouping (default: {{.Merges.ScopePrefix}})
     -merges-summary-labels        Labels for summary group {{if .Merges.SummaryLabels}}(default: {{Join .Merges.SummaryLabels ","}}){{end}}
     -merges-removed-labels        Labels for removed group {{if .Merges.RemovedLabels}}(default: {{Join .Merges.RemovedLabels ","}}){{end}}
     -merges-breaking-labels       Labels for breaking group {{if .Merges.BreakingLabels}}(default: {{Join .Merges.BreakingLabels ","}}){{end}}
@@ -75,8 +122,36 @@ const helpTemplate = `
     -merges-enhancement-labels    Labels for enhancement group {{if .Merges.EnhancementLabels}}(default: {{Join .Merges.EnhancementLabels ","}}){{end}}
     -merges-bug-labels            Labels for bug group {{if .Merges.BugLabels}}(default: {{Join .Merges.BugLabels ","}}){{end}}
     -merges-security-labels       Labels for security group {{if .Merges.SecurityLabels}}(default: {{Join .Merges.SecurityLabels ","}}){{end}}
+    -merges-replace-groups        Replace the default label groups with the custom groups defined in the spec file (default: {{.Merges.ReplaceGroups}})
+
+    -commits-selection            Include commits pushed directly to the branch without pull/merge requests (values: none|all) (default: {{.Commits.Selection}})
+    -commits-exclude-merges       Exclude merge commits (default: {{.Commits.ExcludeMerges}})
+    -commits-exclude-bots         Exclude commits by bots (default: {{.Commits.ExcludeBots}})
+    -commits-skip-markers         Exclude commits with these markers in their messages {{if .Commits.SkipMarkers}}(default: {{Join .Commits.SkipMarkers ","}}){{end}}
+
+    -changes-dedup                Deduplication of issues and pull/merge requests closing them (values: none|issue|merge|both) (default: {{.Changes.Dedup}})
+    -changes-layout               Layout of issues and pull/merge requests in releases (values: split|unified) (default: {{.Changes.Layout}})
+                                  The unified layout groups issues and pull/merge requests together using the issues grouping options
+    -breaking-title               Detect breaking changes by the Conventional Commits ! marker in titles (default: {{.Changes.Breaking.Title}})
+    -breaking-body                Detect breaking changes by the keyword sections in descriptions (default: {{.Changes.Breaking.Body}})
+    -breaking-commit              Detect breaking changes by the keyword sections in merge commit messages (default: {{.Changes.Breaking.Commit}})
+    -breaking-keywords            Keywords for breaking change sections {{if .Changes.Breaking.Keywords}}(default: {{Join .Changes.Breaking.Keywords ","}}){{end}}
+                                  Detected breaking changes are listed first in each release along with their migration notes
+    -directives-skip-markers      Skip issues and pull/merge requests with these markers in their titles or descriptions {{if .Changes.Directives.SkipMarkers}}(default: {{Join .Changes.Directives.SkipMarkers ","}}){{end}}
+    -directives-keyword           The keyword for directive lines in descriptions (i.e. 'changelog: skip' or 'changelog: <title>') {{if .Changes.Directives.Keyword}}(default: {{.Changes.Directives.Keyword}}){{end}}
+
+    -jira-url                     The base URL of a Jira instance for enriching changes with the tickets they reference {{if .Jira.URL}}(default: {{.Jira.URL}}){{end}}
+    -jira-user                    The Jira user for basic authentication with an API token (bearer authentication if not set) {{if .Jira.User}}(default: {{.Jira.User}}){{end}}
+    {{ yellow "-jira-token                   The Jira API token or personal access token" }}
+    {{ yellow "                              The default value is read from the CHANGELOG_JIRA_TOKEN environment variable" }}
+    -jira-key-regex               A regex for extracting Jira keys (i.e. PROJ-1234) {{if .Jira.KeyRegex}}(default: {{.Jira.KeyRegex}}){{end}}
+    -jira-sources                 Extract Jira keys from these sources (values: title|body|branch) {{if .Jira.Sources}}(default: {{Join .Jira.Sources ","}}){{end}}
 
     -release-url                  An external release URL with the '{tag}' placeholder for the release tag
+    -contributors                 Add a list of contributors to each release (default: {{.Content.Contributors}})
+    -summary                      Add a summary to each release from the descriptions of summary changes and release-notes blocks (default: {{.Content.Summary}})
+    -tag-message                  Add the message of annotated tags to the top of each release (default: {{.Content.TagMessage}})
+    -stats                        Add a line of statistics to each release (i.e. number of changes and median lead time) (default: {{.Content.Stats}})
 
   Examples:
 
@@ -84,12 +159,14 @@ const helpTemplate = `
     changelog -access-token=<your-access-token>
     changelog -access-token=<your-access-token> -base=HISTORY.md
     changelog -access-token=<your-access-token> -future-tag=v0.1.0
+    changelog lint -access-token=<your-access-token> -json
 
 `
 
 const format = `
 Specifications
 Repo:
+  Remote:             %s
   Platform:           %s
   Path:               %s
   AccessToken:        %s
@@ -98,17 +175,29 @@ General:
   Base:               %s
   Print:              %t
   Verbose:            %t
+  JSON:               %t
+  Provider:           %s
 Tags:
   From:               %s
   To:                 %s
   Future:             %s
+  Unreleased:         %t
+  Prerelease:         %s
+  PrereleaseOrigin:   %t
+  Date:               %s
   Exclude:            %s
   ExcludeRegex:       %s
 Issues:
   Selection:          %s
   IncludeLabels:      %s
   ExcludeLabels:      %s
+  IncludeAuthors:     %s
+  ExcludeAuthors:     %s
+  ExcludeAuthorsRegex: %s
+  Bots:               %s
+  Filter:             %s
   Grouping:           %s
+  ScopePrefix:        %s
   SummaryLabels:      %s
   RemovedLabels:      %s
   BreakingLabels:     %s
@@ -117,12 +206,21 @@ Issues:
   EnhancementLabels:  %s
   BugLabels:          %s
   SecurityLabels:     %s
+  Groups:             %v
+  ReplaceGroups:      %t
 Merges:
   Selection:          %s
   Branch:             %s
   IncludeLabels:      %s
   ExcludeLabels:      %s
+  IncludeAuthors:     %s
+  ExcludeAuthors:     %s
+  ExcludeAuthorsRegex: %s
+  Bots:               %s
+  Filter:             %s
+  ReleaseNotes:       %t
   Grouping:           %s
+  ScopePrefix:        %s
   SummaryLabels:      %s
   RemovedLabels:      %s
   BreakingLabels:     %s
@@ -131,8 +229,41 @@ Merges:
   EnhancementLabels:  %s
   BugLabels:          %s
   SecurityLabels:     %s
+  Groups:             %v
+  ReplaceGroups:      %t
+Commits:
+  Selection:          %s
+  ExcludeMerges:      %t
+  ExcludeBots:        %t
+  SkipMarkers:        %s
+Changes:
+  Dedup:              %s
+  Layout:             %s
+  Breaking:
+    Title:            %t
+    Body:             %t
+    Commit:           %t
+    Keywords:         %s
+  Directives:
+    SkipMarkers:      %s
+    Keyword:          %s
+Jira:
+  URL:                %s
+  User:               %s
+  Token:              %s
+  KeyRegex:           %s
+  Sources:            %s
+Hooks:
+  PreGenerate:        %v
+  PostRender:         %v
+  PostWrite:          %v
+Sinks:                %v
 Content:
   ReleaseURL:         %s
+  Contributors:       %t
+  Summary:            %t
+  TagMessage:         %t
+  Stats:              %t
 `
 
 // Platform is the platform for managing a Git remote repository.
@@ -146,27 +277,75 @@ const (
 )
 
 // Repo has the specifications for a git repository.
+// Remote is the name of the git remote for reading the Platform and Path.
+// If the Path is set explicitly, the git remote is not read at all.
 type Repo struct {
-	Platform    Platform `yaml:"-"`
-	Path        string   `yaml:"-"`
+	Remote      string   `yaml:"remote" flag:"remote"`
+	Platform    Platform `yaml:"platform" flag:"platform"`
+	Path        string   `yaml:"path" flag:"path"`
 	AccessToken string   `yaml:"-" flag:"access-token"`
 }
 
 // General has the general specifications.
 type General struct {
-	File    string `yaml:"file" flag:"file"`
-	Base    string `yaml:"base" flag:"base"`
-	Print   bool   `yaml:"print" flag:"print"`
-	Verbose bool   `yaml:"verbose" flag:"verbose"`
+	File     string `yaml:"file" flag:"file"`
+	Base     string `yaml:"base" flag:"base"`
+	Print    bool   `yaml:"print" flag:"print"`
+	Verbose  bool   `yaml:"verbose" flag:"verbose"`
+	JSON     bool   `yaml:"-" flag:"json"`
+	Provider string `yaml:"provider" flag:"provider"`
 }
 
 // Tags has the specifications for identifying git tags.
 type Tags struct {
-	From         string   `yaml:"-" flag:"from-tag"`
-	To           string   `yaml:"-" flag:"to-tag"`
-	Future       string   `yaml:"-" flag:"future-tag"`
-	Exclude      []string `yaml:"exclude" flag:"exclude-tags"`
-	ExcludeRegex string   `yaml:"exclude-regex" flag:"exclude-tags-regex"`
+	From             string     `yaml:"-" flag:"from-tag"`
+	To               string     `yaml:"-" flag:"to-tag"`
+	Future           string     `yaml:"-" flag:"future-tag"`
+	Unreleased       bool       `yaml:"unreleased" flag:"unreleased"`
+	Prerelease       Prerelease `yaml:"prerelease" flag:"prerelease"`
+	PrereleaseOrigin bool       `yaml:"prerelease-origin" flag:"prerelease-origin"`
+	Date             TagDate    `yaml:"date" flag:"tag-date"`
+	Exclude          []string   `yaml:"exclude" flag:"exclude-tags"`
+	ExcludeRegex     string     `yaml:"exclude-regex" flag:"exclude-tags-regex"`
+}
+
+// TagDate determines which date of a tag is used as the release date.
+type TagDate string
+
+const (
+	// TagDateCommit uses the date of the commit that a tag refers to.
+	TagDateCommit = TagDate("commit")
+	// TagDateTag uses the date of the annotated tag (lightweight tags fall back to the commit date).
+	TagDateTag = TagDate("tag")
+)
+
+func (d TagDate) validate() error {
+	switch d {
+	case "", TagDateCommit, TagDateTag:
+		return nil
+	default:
+		return fmt.Errorf("invalid date: %s", d)
+	}
+}
+
+// Prerelease determines how pre-release tags (i.e. v2.0.0-rc.1) are handled.
+type Prerelease string
+
+const (
+	// PrereleaseSeparate adds a separate section for every pre-release tag.
+	PrereleaseSeparate = Prerelease("separate")
+	// PrereleaseRollup adds the changes of pre-release tags to the section of their final release tag,
+	// or to the section of the latest pre-release tag until the final release tag is created.
+	PrereleaseRollup = Prerelease("rollup")
+)
+
+func (p Prerelease) validate() error {
+	switch p {
+	case "", PrereleaseSeparate, PrereleaseRollup:
+		return nil
+	default:
+		return fmt.Errorf("invalid prerelease: %s", p)
+	}
 }
 
 // Selection determines how changes should be selected for a changelog.
@@ -181,7 +360,31 @@ const (
 	SelectionLabeled = Selection("labeled")
 )
 
+// Bots determines how changes by bots are handled.
+type Bots string
+
+const (
+	// BotsInclude includes changes by bots like any other change.
+	BotsInclude = Bots("include")
+	// BotsExclude excludes changes by bots.
+	BotsExclude = Bots("exclude")
+	// BotsGroup groups changes by bots in a separate group.
+	BotsGroup = Bots("group")
+	// BotsCollapse collapses changes by bots into a single entry.
+	BotsCollapse = Bots("collapse")
+)
+
+func (b Bots) validate() error {
+	switch b {
+	case "", BotsInclude, BotsExclude, BotsGroup, BotsCollapse:
+		return nil
+	default:
+		return fmt.Errorf("invalid bots: %s", b)
+	}
+}
+
 // Grouping determnies how changes are grouped together.
+// Groupings can be chained using > for nested groups (i.e. milestone>label).
 type Grouping string
 
 const (
@@ -191,31 +394,118 @@ const (
 	GroupingMilestone = Grouping("milestone")
 	// GroupingLabel groups changes by labels.
 	GroupingLabel = Grouping("label")
+	// GroupingScope groups changes by scope labels (labels with the scope prefix).
+	GroupingScope = Grouping("scope")
 )
 
+// Chain returns the chain of groupings from the outermost to the innermost.
+func (g Grouping) Chain() []Grouping {
+	chain := []Grouping{}
+	for _, sub := range strings.Split(string(g), ">") {
+		chain = append(chain, Grouping(strings.TrimSpace(sub)))
+	}
+
+	return chain
+}
+
+func (g Grouping) validate() error {
+	if g == "" {
+		return nil
+	}
+
+	chain := g.Chain()
+	for _, sub := range chain {
+		switch sub {
+		case GroupingSimple:
+			if len(chain) > 1 {
+				return fmt.Errorf("invalid grouping: %s cannot be chained", sub)
+			}
+		case GroupingMilestone, GroupingLabel, GroupingScope:
+		default:
+			return fmt.Errorf("invalid grouping: %s", g)
+		}
+	}
+
+	return nil
+}
+
 // LabelGroup represents a group of issues or merges characterized by a set of labels.
+// Groups are sorted by their orders and groups without an order come last.
 type LabelGroup struct {
-	Title  string
-	Labels []string
+	Title      string   `yaml:"title"`
+	Labels     []string `yaml:"labels"`
+	LabelRegex string   `yaml:"label-regex"`
+	Order      int      `yaml:"order"`
+	Emoji      string   `yaml:"emoji"`
+}
+
+// sortLabelGroups sorts label groups by their orders.
+func sortLabelGroups(groups []LabelGroup) {
+	order := func(g LabelGroup) int {
+		if g.Order == 0 {
+			return math.MaxInt32
+		}
+		return g.Order
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return order(groups[i]) < order(groups[j])
+	})
+}
+
+// mergeLabelGroups merges custom label groups into default label groups.
+// A custom group with the same title as a default group replaces it.
+func mergeLabelGroups(defaults, custom []LabelGroup, replace bool) []LabelGroup {
+	groups := []LabelGroup{}
+
+	if !replace {
+		for _, d := range defaults {
+			overridden := false
+			for _, c := range custom {
+				if c.Title == d.Title {
+					overridden = true
+					break
+				}
+			}
+
+			if !overridden {
+				groups = append(groups, d)
+			}
+		}
+	}
+
+	groups = append(groups, custom...)
+	sortLabelGroups(groups)
+
+	return groups
 }
 
 // Issues has the specifications for fetching, flitering, and grouping issues.
 type Issues struct {
-	Selection         Selection `yaml:"selection" flag:"issues-selection"`
-	IncludeLabels     []string  `yaml:"include-labels" flag:"issues-include-labels"`
-	ExcludeLabels     []string  `yaml:"exclude-labels" flag:"issues-exclude-labels"`
-	Grouping          Grouping  `yaml:"grouping" flag:"issues-grouping"`
-	SummaryLabels     []string  `yaml:"summary-labels" flag:"issues-summary-labels"`
-	RemovedLabels     []string  `yaml:"removed-labels" flag:"issues-removed-labels"`
-	BreakingLabels    []string  `yaml:"breaking-labels" flag:"issues-breaking-labels"`
-	DeprecatedLabels  []string  `yaml:"deprecated-labels" flag:"issues-deprecated-labels"`
-	FeatureLabels     []string  `yaml:"feature-labels" flag:"issues-feature-labels"`
-	EnhancementLabels []string  `yaml:"enhancement-labels" flag:"issues-enhancement-labels"`
-	BugLabels         []string  `yaml:"bug-labels" flag:"issues-bug-labels"`
-	SecurityLabels    []string  `yaml:"security-labels" flag:"issues-security-labels"`
+	Selection           Selection    `yaml:"selection" flag:"issues-selection"`
+	IncludeLabels       []string     `yaml:"include-labels" flag:"issues-include-labels"`
+	ExcludeLabels       []string     `yaml:"exclude-labels" flag:"issues-exclude-labels"`
+	IncludeAuthors      []string     `yaml:"include-authors" flag:"issues-include-authors"`
+	ExcludeAuthors      []string     `yaml:"exclude-authors" flag:"issues-exclude-authors"`
+	ExcludeAuthorsRegex string       `yaml:"exclude-authors-regex" flag:"issues-exclude-authors-regex"`
+	Bots                Bots         `yaml:"bots" flag:"issues-bots"`
+	Filter              string       `yaml:"filter" flag:"issues-filter"`
+	Grouping            Grouping     `yaml:"grouping" flag:"issues-grouping"`
+	ScopePrefix         string       `yaml:"scope-prefix" flag:"issues-scope-prefix"`
+	SummaryLabels       []string     `yaml:"summary-labels" flag:"issues-summary-labels"`
+	RemovedLabels       []string     `yaml:"removed-labels" flag:"issues-removed-labels"`
+	BreakingLabels      []string     `yaml:"breaking-labels" flag:"issues-breaking-labels"`
+	DeprecatedLabels    []string     `yaml:"deprecated-labels" flag:"issues-deprecated-labels"`
+	FeatureLabels       []string     `yaml:"feature-labels" flag:"issues-feature-labels"`
+	EnhancementLabels   []string     `yaml:"enhancement-labels" flag:"issues-enhancement-labels"`
+	BugLabels           []string     `yaml:"bug-labels" flag:"issues-bug-labels"`
+	SecurityLabels      []string     `yaml:"security-labels" flag:"issues-security-labels"`
+	Groups              []LabelGroup `yaml:"groups"`
+	ReplaceGroups       bool         `yaml:"replace-groups" flag:"issues-replace-groups"`
 }
 
 // LabelGroups returns the label groups for issues.
+// Custom groups extend or replace the default groups.
 func (i Issues) LabelGroups() []LabelGroup {
 	groups := []LabelGroup{}
 
@@ -223,6 +513,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Release Summary",
 			Labels: i.SummaryLabels,
+			Order:  10,
 		})
 	}
 
@@ -230,6 +521,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Removed",
 			Labels: i.RemovedLabels,
+			Order:  20,
 		})
 	}
 
@@ -237,6 +529,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Breaking Changes",
 			Labels: i.BreakingLabels,
+			Order:  30,
 		})
 	}
 
@@ -244,6 +537,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Deprecated",
 			Labels: i.DeprecatedLabels,
+			Order:  40,
 		})
 	}
 
@@ -251,6 +545,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "New Features",
 			Labels: i.FeatureLabels,
+			Order:  50,
 		})
 	}
 
@@ -258,6 +553,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Enhancements",
 			Labels: i.EnhancementLabels,
+			Order:  60,
 		})
 	}
 
@@ -265,6 +561,7 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Fixed Bugs",
 			Labels: i.BugLabels,
+			Order:  70,
 		})
 	}
 
@@ -272,30 +569,41 @@ func (i Issues) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Security Fixes",
 			Labels: i.SecurityLabels,
+			Order:  80,
 		})
 	}
 
-	return groups
+	return mergeLabelGroups(groups, i.Groups, i.ReplaceGroups)
 }
 
 // Merges has the specifications for fetching, flitering, and grouping pull/merge requests.
 type Merges struct {
-	Selection         Selection `yaml:"selection" flag:"merges-selection"`
-	Branch            string    `yaml:"branch" flag:"merges-branch"`
-	IncludeLabels     []string  `yaml:"include-labels" flag:"merges-include-labels"`
-	ExcludeLabels     []string  `yaml:"exclude-labels" flag:"merges-exclude-labels"`
-	Grouping          Grouping  `yaml:"grouping" flag:"merges-grouping"`
-	SummaryLabels     []string  `yaml:"summary-labels" flag:"merges-summary-labels"`
-	RemovedLabels     []string  `yaml:"removed-labels" flag:"merges-removed-labels"`
-	BreakingLabels    []string  `yaml:"breaking-labels" flag:"merges-breaking-labels"`
-	DeprecatedLabels  []string  `yaml:"deprecated-labels" flag:"merges-deprecated-labels"`
-	FeatureLabels     []string  `yaml:"feature-labels" flag:"merges-feature-labels"`
-	EnhancementLabels []string  `yaml:"enhancement-labels" flag:"merges-enhancement-labels"`
-	BugLabels         []string  `yaml:"bug-labels" flag:"merges-bug-labels"`
-	SecurityLabels    []string  `yaml:"security-labels" flag:"merges-security-labels"`
+	Selection           Selection    `yaml:"selection" flag:"merges-selection"`
+	Branch              string       `yaml:"branch" flag:"merges-branch"`
+	IncludeLabels       []string     `yaml:"include-labels" flag:"merges-include-labels"`
+	ExcludeLabels       []string     `yaml:"exclude-labels" flag:"merges-exclude-labels"`
+	IncludeAuthors      []string     `yaml:"include-authors" flag:"merges-include-authors"`
+	ExcludeAuthors      []string     `yaml:"exclude-authors" flag:"merges-exclude-authors"`
+	ExcludeAuthorsRegex string       `yaml:"exclude-authors-regex" flag:"merges-exclude-authors-regex"`
+	Bots                Bots         `yaml:"bots" flag:"merges-bots"`
+	Filter              string       `yaml:"filter" flag:"merges-filter"`
+	ReleaseNotes        bool         `yaml:"release-notes" flag:"merges-release-notes"`
+	Grouping            Grouping     `yaml:"grouping" flag:"merges-grouping"`
+	ScopePrefix         string       `yaml:"scope-prefix" flag:"merges-scope-prefix"`
+	SummaryLabels       []string     `yaml:"summary-labels" flag:"merges-summary-labels"`
+	RemovedLabels       []string     `yaml:"removed-labels" flag:"merges-removed-labels"`
+	BreakingLabels      []string     `yaml:"breaking-labels" flag:"merges-breaking-labels"`
+	DeprecatedLabels    []string     `yaml:"deprecated-labels" flag:"merges-deprecated-labels"`
+	FeatureLabels       []string     `yaml:"feature-labels" flag:"merges-feature-labels"`
+	EnhancementLabels   []string     `yaml:"enhancement-labels" flag:"merges-enhancement-labels"`
+	BugLabels           []string     `yaml:"bug-labels" flag:"merges-bug-labels"`
+	SecurityLabels      []string     `yaml:"security-labels" flag:"merges-security-labels"`
+	Groups              []LabelGroup `yaml:"groups"`
+	ReplaceGroups       bool         `yaml:"replace-groups" flag:"merges-replace-groups"`
 }
 
 // LabelGroups returns the label groups for merges.
+// Custom groups extend or replace the default groups.
 func (m Merges) LabelGroups() []LabelGroup {
 	groups := []LabelGroup{}
 
@@ -303,6 +611,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Release Summary",
 			Labels: m.SummaryLabels,
+			Order:  10,
 		})
 	}
 
@@ -310,6 +619,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Removed",
 			Labels: m.RemovedLabels,
+			Order:  20,
 		})
 	}
 
@@ -317,6 +627,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Breaking Changes",
 			Labels: m.BreakingLabels,
+			Order:  30,
 		})
 	}
 
@@ -324,6 +635,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Deprecated",
 			Labels: m.DeprecatedLabels,
+			Order:  40,
 		})
 	}
 
@@ -331,6 +643,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "New Features",
 			Labels: m.FeatureLabels,
+			Order:  50,
 		})
 	}
 
@@ -338,6 +651,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Enhancements",
 			Labels: m.EnhancementLabels,
+			Order:  60,
 		})
 	}
 
@@ -345,6 +659,7 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Fixed Bugs",
 			Labels: m.BugLabels,
+			Order:  70,
 		})
 	}
 
@@ -352,15 +667,195 @@ func (m Merges) LabelGroups() []LabelGroup {
 		groups = append(groups, LabelGroup{
 			Title:  "Security Fixes",
 			Labels: m.SecurityLabels,
+			Order:  80,
 		})
 	}
 
-	return groups
+	return mergeLabelGroups(groups, m.Groups, m.ReplaceGroups)
+}
+
+// Commits has the specifications for listing commits pushed directly to the branch without pull/merge requests.
+type Commits struct {
+	Selection     Selection `yaml:"selection" flag:"commits-selection"`
+	ExcludeMerges bool      `yaml:"exclude-merges" flag:"commits-exclude-merges"`
+	ExcludeBots   bool      `yaml:"exclude-bots" flag:"commits-exclude-bots"`
+	SkipMarkers   []string  `yaml:"skip-markers" flag:"commits-skip-markers"`
+}
+
+// Dedup determines how an issue and the pull/merge requests closing it are deduplicated.
+type Dedup string
+
+const (
+	// DedupNone keeps both issues and pull/merge requests closing them.
+	DedupNone = Dedup("none")
+	// DedupIssue keeps issues and removes pull/merge requests closing them.
+	DedupIssue = Dedup("issue")
+	// DedupMerge keeps pull/merge requests and removes issues closed by them.
+	DedupMerge = Dedup("merge")
+	// DedupBoth combines issues and pull/merge requests closing them into single issue entries.
+	DedupBoth = Dedup("both")
+)
+
+func (d Dedup) validate() error {
+	switch d {
+	case "", DedupNone, DedupIssue, DedupMerge, DedupBoth:
+		return nil
+	default:
+		return fmt.Errorf("invalid dedup: %s", d)
+	}
+}
+
+// Layout determines how issues and pull/merge requests are laid out in a release.
+type Layout string
+
+const (
+	// LayoutSplit groups issues and pull/merge requests separately.
+	LayoutSplit = Layout("split")
+	// LayoutUnified groups issues and pull/merge requests together using the issues grouping specifications.
+	LayoutUnified = Layout("unified")
+)
+
+func (l Layout) validate() error {
+	switch l {
+	case "", LayoutSplit, LayoutUnified:
+		return nil
+	default:
+		return fmt.Errorf("invalid layout: %s", l)
+	}
+}
+
+// Breaking has the specifications for detecting breaking changes beyond breaking labels.
+// Title detects the Conventional Commits ! marker in titles (i.e. feat!: or feat(api)!:).
+// Body and Commit detect the keyword sections in descriptions and merge commit messages respectively.
+type Breaking struct {
+	Title    bool     `yaml:"title" flag:"breaking-title"`
+	Body     bool     `yaml:"body" flag:"breaking-body"`
+	Commit   bool     `yaml:"commit" flag:"breaking-commit"`
+	Keywords []string `yaml:"keywords" flag:"breaking-keywords"`
+}
+
+// Enabled determines whether or not any detection rule is enabled.
+func (b Breaking) Enabled() bool {
+	return b.Title || b.Body || b.Commit
+}
+
+// Directives has the specifications for changelog directives in titles and descriptions of issues and pull/merge requests.
+// A change is skipped if it has one of the SkipMarkers or a Keyword line with the skip value (i.e. changelog: skip).
+// Any other value of a Keyword line (i.e. changelog: <title>) overrides the title of a change.
+type Directives struct {
+	SkipMarkers []string `yaml:"skip-markers" flag:"directives-skip-markers"`
+	Keyword     string   `yaml:"keyword" flag:"directives-keyword"`
+}
+
+// Changes has the specifications for combining issues and pull/merge requests.
+type Changes struct {
+	Dedup      Dedup      `yaml:"dedup" flag:"changes-dedup"`
+	Layout     Layout     `yaml:"layout" flag:"changes-layout"`
+	Breaking   Breaking   `yaml:"breaking"`
+	Directives Directives `yaml:"directives"`
+}
+
+const (
+	// JiraSourceTitle extracts Jira keys from the titles of changes.
+	JiraSourceTitle = "title"
+	// JiraSourceBody extracts Jira keys from the descriptions of changes.
+	JiraSourceBody = "body"
+	// JiraSourceBranch extracts Jira keys from the source branches of pull/merge requests.
+	JiraSourceBranch = "branch"
+)
+
+// Jira has the specifications for enriching changes with the Jira tickets they reference.
+// Enrichment is disabled if URL is empty.
+type Jira struct {
+	URL      string   `yaml:"url" flag:"jira-url"`
+	User     string   `yaml:"user" flag:"jira-user"`
+	Token    string   `yaml:"-" flag:"jira-token"`
+	KeyRegex string   `yaml:"key-regex" flag:"jira-key-regex"`
+	Sources  []string `yaml:"sources" flag:"jira-sources"`
+}
+
+// Hook is a lifecycle hook that runs a shell command or a Go template.
+// Commands receive the hook data as JSON on stdin and templates transform the rendered content.
+type Hook struct {
+	Command  string `yaml:"command"`
+	Template string `yaml:"template"`
+}
+
+// Hooks has the specifications for the lifecycle hooks of generating changelogs.
+// PreGenerate hooks run before rendering, PostRender hooks run before writing, and PostWrite hooks run after writing the changelog.
+type Hooks struct {
+	PreGenerate []Hook `yaml:"pre-generate"`
+	PostRender  []Hook `yaml:"post-render"`
+	PostWrite   []Hook `yaml:"post-write"`
+}
+
+// validateHooks checks the hooks of a lifecycle stage.
+// Templates are only allowed if they can transform the rendered content.
+func validateHooks(stage string, hooks []Hook, templates bool) error {
+	for _, h := range hooks {
+		switch {
+		case h.Command == "" && h.Template == "":
+			return fmt.Errorf("%s hook: command or template is required", stage)
+		case h.Command != "" && h.Template != "":
+			return fmt.Errorf("%s hook: command and template cannot be used together", stage)
+		case h.Template != "" && !templates:
+			return fmt.Errorf("%s hook: template is only supported for post-render", stage)
+		case h.Template != "":
+			if _, err := template.New(stage).Parse(h.Template); err != nil {
+				return fmt.Errorf("%s hook: %s", stage, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SinkType determines the format of notifications sent to a sink.
+type SinkType string
+
+const (
+	// SinkSlack sends notifications to a Slack incoming webhook using blocks.
+	SinkSlack = SinkType("slack")
+	// SinkTeams sends notifications to a Microsoft Teams incoming webhook using an adaptive card.
+	SinkTeams = SinkType("teams")
+	// SinkWebhook sends notifications to a generic webhook as raw JSON.
+	SinkWebhook = SinkType("webhook")
+)
+
+// Sink has the specifications for sending notifications about new releases to a webhook.
+// The URL can refer to environment variables (i.e. ${SLACK_WEBHOOK_URL}) and the optional template formats the message.
+type Sink struct {
+	Type     SinkType `yaml:"type"`
+	URL      string   `yaml:"url"`
+	Template string   `yaml:"template"`
+}
+
+// validate checks a sink.
+func (s Sink) validate() error {
+	if s.Type != SinkSlack && s.Type != SinkTeams && s.Type != SinkWebhook {
+		return fmt.Errorf("invalid sink type: %s", s.Type)
+	}
+
+	if s.URL == "" {
+		return fmt.Errorf("%s sink: url is required", s.Type)
+	}
+
+	if s.Template != "" {
+		if _, err := template.New(string(s.Type)).Parse(s.Template); err != nil {
+			return fmt.Errorf("%s sink: %s", s.Type, err)
+		}
+	}
+
+	return nil
 }
 
 // Content has the specifications for the content of changelogs.
 type Content struct {
-	ReleaseURL string `yaml:"release-url" flag:"release-url"`
+	ReleaseURL   string `yaml:"release-url" flag:"release-url"`
+	Contributors bool   `yaml:"contributors" flag:"contributors"`
+	Summary      bool   `yaml:"summary" flag:"summary"`
+	TagMessage   bool   `yaml:"tag-message" flag:"tag-message"`
+	Stats        bool   `yaml:"stats" flag:"stats"`
 }
 
 // GetReleaseURL returns the actual release url for a tag/release.
@@ -372,11 +867,16 @@ func (c Content) GetReleaseURL(tag string) string {
 type Spec struct {
 	Help    bool    `yaml:"-" flag:"help"`
 	Version bool    `yaml:"-" flag:"version"`
-	Repo    Repo    `yaml:"-"`
+	Repo    Repo    `yaml:"repo"`
 	General General `yaml:"general"`
 	Tags    Tags    `yaml:"tags"`
 	Issues  Issues  `yaml:"issues"`
 	Merges  Merges  `yaml:"merges"`
+	Commits Commits `yaml:"commits"`
+	Changes Changes `yaml:"changes"`
+	Jira    Jira    `yaml:"jira"`
+	Hooks   Hooks   `yaml:"hooks"`
+	Sinks   []Sink  `yaml:"sinks"`
 	Content Content `yaml:"content"`
 }
 
@@ -387,54 +887,115 @@ func Default() Spec {
 		Help:    false,
 		Version: false,
 		Repo: Repo{
+			Remote:      "",
 			Platform:    Platform(""),
 			Path:        "",
 			AccessToken: os.Getenv(envVarName),
 		},
 		General: General{
-			File:    "CHANGELOG.md",
-			Base:    "",
-			Print:   false,
-			Verbose: false,
+			File:     "CHANGELOG.md",
+			Base:     "",
+			Print:    false,
+			Verbose:  false,
+			JSON:     false,
+			Provider: "",
 		},
 		Tags: Tags{
-			From:         "",
-			To:           "",
-			Future:       "",
-			Exclude:      []string{},
-			ExcludeRegex: "",
+			From:             "",
+			To:               "",
+			Future:           "",
+			Unreleased:       false,
+			Prerelease:       PrereleaseSeparate,
+			PrereleaseOrigin: false,
+			Date:             TagDateCommit,
+			Exclude:          []string{},
+			ExcludeRegex:     "",
 		},
 		Issues: Issues{
-			Selection:         SelectionAll,
-			IncludeLabels:     nil, // All labels included
-			ExcludeLabels:     []string{"duplicate", "invalid", "question", "wontfix"},
-			Grouping:          GroupingLabel,
-			SummaryLabels:     []string{"summary", "release-summary"},
-			RemovedLabels:     []string{"removed"},
-			BreakingLabels:    []string{"breaking", "backward-incompatible"},
-			DeprecatedLabels:  []string{"deprecated"},
-			FeatureLabels:     []string{"feature"},
-			EnhancementLabels: []string{"enhancement"},
-			BugLabels:         []string{"bug"},
-			SecurityLabels:    []string{"security"},
+			Selection:           SelectionAll,
+			IncludeLabels:       nil, // All labels included
+			ExcludeLabels:       []string{"duplicate", "invalid", "question", "wontfix"},
+			IncludeAuthors:      nil, // All authors included
+			ExcludeAuthors:      nil, // No author excluded
+			ExcludeAuthorsRegex: "",
+			Bots:                BotsInclude,
+			Filter:              "", // No filter expression
+			Grouping:            GroupingLabel,
+			ScopePrefix:         "area/",
+			SummaryLabels:       []string{"summary", "release-summary"},
+			RemovedLabels:       []string{"removed"},
+			BreakingLabels:      []string{"breaking", "backward-incompatible"},
+			DeprecatedLabels:    []string{"deprecated"},
+			FeatureLabels:       []string{"feature"},
+			EnhancementLabels:   []string{"enhancement"},
+			BugLabels:           []string{"bug"},
+			SecurityLabels:      []string{"security"},
+			Groups:              nil, // No custom group
+			ReplaceGroups:       false,
 		},
 		Merges: Merges{
-			Selection:         SelectionAll,
-			Branch:            "",  // Default branch
-			IncludeLabels:     nil, // All labels
-			ExcludeLabels:     nil, // No label excluded
-			Grouping:          GroupingSimple,
-			SummaryLabels:     []string{},
-			RemovedLabels:     []string{},
-			BreakingLabels:    []string{},
-			DeprecatedLabels:  []string{},
-			FeatureLabels:     []string{},
-			EnhancementLabels: []string{},
-			BugLabels:         []string{},
-			SecurityLabels:    []string{},
+			Selection:           SelectionAll,
+			Branch:              "",  // Default branch
+			IncludeLabels:       nil, // All labels
+			ExcludeLabels:       nil, // No label excluded
+			IncludeAuthors:      nil, // All authors included
+			ExcludeAuthors:      nil, // No author excluded
+			ExcludeAuthorsRegex: "",
+			Bots:                BotsInclude,
+			Filter:              "", // No filter expression
+			ReleaseNotes:        false,
+			Grouping:            GroupingSimple,
+			ScopePrefix:         "area/",
+			SummaryLabels:       []string{},
+			RemovedLabels:       []string{},
+			BreakingLabels:      []string{},
+			DeprecatedLabels:    []string{},
+			FeatureLabels:       []string{},
+			EnhancementLabels:   []string{},
+			BugLabels:           []string{},
+			SecurityLabels:      []string{},
+			Groups:              nil, // No custom group
+			ReplaceGroups:       false,
+		},
+		Commits: Commits{
+			Selection:     SelectionNone,
+			ExcludeMerges: true,
+			ExcludeBots:   true,
+			SkipMarkers:   []string{"[skip changelog]", "[changelog skip]"},
+		},
+		Changes: Changes{
+			Dedup:  DedupNone,
+			Layout: LayoutSplit,
+			Breaking: Breaking{
+				Title:    false,
+				Body:     false,
+				Commit:   false,
+				Keywords: []string{"BREAKING CHANGE", "BREAKING-CHANGE"},
+			},
+			Directives: Directives{
+				SkipMarkers: []string{},
+				Keyword:     "",
+			},
+		},
+		Jira: Jira{
+			URL:      "",
+			User:     "",
+			Token:    os.Getenv(jiraEnvVarName),
+			KeyRegex: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+			Sources:  []string{JiraSourceTitle, JiraSourceBranch},
 		},
+		Hooks: Hooks{
+			PreGenerate: nil, // No hook
+			PostRender:  nil, // No hook
+			PostWrite:   nil, // No hook
+		},
+		Sinks: nil, // No sink
 		Content: Content{
-			ReleaseURL: "",
+			ReleaseURL:   "",
+			Contributors: false,
+			Summary:      false,
+			TagMessage:   false,
+			Stats:        false,
 		},
 	}
 }
@@ -464,6 +1025,119 @@ func (s Spec) FromFile() (Spec, error) {
 	return s, nil
 }
 
+// Validate checks the specifications for errors, so they can be reported before generating a changelog.
+func (s Spec) Validate() error {
+	if s.Repo.Platform != "" && s.Repo.Path == "" {
+		return fmt.Errorf("repo platform cannot be used without path")
+	}
+
+	if s.Repo.Path != "" && s.Repo.Platform == "" && s.General.Provider == "" {
+		return fmt.Errorf("repo path cannot be used without platform or provider")
+	}
+
+	if s.Tags.Future != "" && s.Tags.Unreleased {
+		return fmt.Errorf("future-tag cannot be used with unreleased")
+	}
+
+	if err := s.Tags.Date.validate(); err != nil {
+		return fmt.Errorf("tags %s", err)
+	}
+
+	if err := s.Tags.Prerelease.validate(); err != nil {
+		return fmt.Errorf("tags %s", err)
+	}
+
+	if err := s.Issues.Grouping.validate(); err != nil {
+		return fmt.Errorf("issues %s", err)
+	}
+
+	if err := s.Issues.Bots.validate(); err != nil {
+		return fmt.Errorf("issues %s", err)
+	}
+
+	if err := s.Merges.Grouping.validate(); err != nil {
+		return fmt.Errorf("merges %s", err)
+	}
+
+	if err := s.Merges.Bots.validate(); err != nil {
+		return fmt.Errorf("merges %s", err)
+	}
+
+	if err := s.Changes.Dedup.validate(); err != nil {
+		return fmt.Errorf("changes %s", err)
+	}
+
+	if err := s.Changes.Layout.validate(); err != nil {
+		return fmt.Errorf("changes %s", err)
+	}
+
+	if s.Commits.Selection == SelectionLabeled {
+		return fmt.Errorf("commits selection cannot be labeled")
+	}
+
+	if s.Jira.URL != "" {
+		if _, err := regexp.Compile(s.Jira.KeyRegex); err != nil {
+			return fmt.Errorf("jira key-regex: %s", err)
+		}
+
+		for _, source := range s.Jira.Sources {
+			if source != JiraSourceTitle && source != JiraSourceBody && source != JiraSourceBranch {
+				return fmt.Errorf("invalid jira source: %s", source)
+			}
+		}
+	}
+
+	if err := validateHooks("pre-generate", s.Hooks.PreGenerate, false); err != nil {
+		return err
+	}
+
+	if err := validateHooks("post-render", s.Hooks.PostRender, true); err != nil {
+		return err
+	}
+
+	if err := validateHooks("post-write", s.Hooks.PostWrite, false); err != nil {
+		return err
+	}
+
+	for _, sink := range s.Sinks {
+		if err := sink.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Issues.Filter != "" {
+		if _, err := filter.Parse(s.Issues.Filter); err != nil {
+			return fmt.Errorf("issues filter: %s", err)
+		}
+	}
+
+	if s.Merges.Filter != "" {
+		if _, err := filter.Parse(s.Merges.Filter); err != nil {
+			return fmt.Errorf("merges filter: %s", err)
+		}
+	}
+
+	for _, g := range s.Issues.Groups {
+		if g.Title == "" {
+			return fmt.Errorf("issues group: title is required")
+		}
+		if _, err := regexp.CompilePOSIX(g.LabelRegex); err != nil {
+			return fmt.Errorf("issues group %q: %s", g.Title, err)
+		}
+	}
+
+	for _, g := range s.Merges.Groups {
+		if g.Title == "" {
+			return fmt.Errorf("merges group: title is required")
+		}
+		if _, err := regexp.CompilePOSIX(g.LabelRegex); err != nil {
+			return fmt.Errorf("merges group %q: %s", g.Title, err)
+		}
+	}
+
+	return nil
+}
+
 // WithRepo sets Repo sepcs and returns a new spec object.
 func (s Spec) WithRepo(domain, path string) Spec {
 	// Leave s.Repo.AccessToken unchanged
@@ -505,13 +1179,24 @@ func (s Spec) PrintHelp() error {
 
 func (s Spec) String() string {
 	return fmt.Sprintf(format,
-		s.Repo.Platform, s.Repo.Path, strings.Repeat("*", len(s.Repo.AccessToken)),
-		s.General.File, s.General.Base, s.General.Print, s.General.Verbose,
-		s.Tags.From, s.Tags.To, s.Tags.Future, s.Tags.Exclude, s.Tags.ExcludeRegex,
+		s.Repo.Remote, s.Repo.Platform, s.Repo.Path, strings.Repeat("*", len(s.Repo.AccessToken)),
+		s.General.File, s.General.Base, s.General.Print, s.General.Verbose, s.General.JSON, s.General.Provider,
+		s.Tags.From, s.Tags.To, s.Tags.Future, s.Tags.Unreleased, s.Tags.Prerelease, s.Tags.PrereleaseOrigin, s.Tags.Date, s.Tags.Exclude, s.Tags.ExcludeRegex,
 		s.Issues.Selection, s.Issues.IncludeLabels, s.Issues.ExcludeLabels,
-		s.Issues.Grouping, s.Issues.SummaryLabels, s.Issues.RemovedLabels, s.Issues.BreakingLabels, s.Issues.DeprecatedLabels, s.Issues.FeatureLabels, s.Issues.EnhancementLabels, s.Issues.BugLabels, s.Issues.SecurityLabels,
+		s.Issues.IncludeAuthors, s.Issues.ExcludeAuthors, s.Issues.ExcludeAuthorsRegex, s.Issues.Bots, s.Issues.Filter,
+		s.Issues.Grouping, s.Issues.ScopePrefix, s.Issues.SummaryLabels, s.Issues.RemovedLabels, s.Issues.BreakingLabels, s.Issues.DeprecatedLabels, s.Issues.FeatureLabels, s.Issues.EnhancementLabels, s.Issues.BugLabels, s.Issues.SecurityLabels,
+		s.Issues.Groups, s.Issues.ReplaceGroups,
 		s.Merges.Selection, s.Merges.Branch, s.Merges.IncludeLabels, s.Merges.ExcludeLabels,
-		s.Merges.Grouping, s.Merges.SummaryLabels, s.Merges.RemovedLabels, s.Merges.BreakingLabels, s.Merges.DeprecatedLabels, s.Merges.FeatureLabels, s.Merges.EnhancementLabels, s.Merges.BugLabels, s.Merges.SecurityLabels,
-		s.Content.ReleaseURL,
+		s.Merges.IncludeAuthors, s.Merges.ExcludeAuthors, s.Merges.ExcludeAuthorsRegex, s.Merges.Bots, s.Merges.Filter, s.Merges.ReleaseNotes,
+		s.Merges.Grouping, s.Merges.ScopePrefix, s.Merges.SummaryLabels, s.Merges.RemovedLabels, s.Merges.BreakingLabels, s.Merges.DeprecatedLabels, s.Merges.FeatureLabels, s.Merges.EnhancementLabels, s.Merges.BugLabels, s.Merges.SecurityLabels,
+		s.Merges.Groups, s.Merges.ReplaceGroups,
+		s.Commits.Selection, s.Commits.ExcludeMerges, s.Commits.ExcludeBots, s.Commits.SkipMarkers,
+		s.Changes.Dedup, s.Changes.Layout,
+		s.Changes.Breaking.Title, s.Changes.Breaking.Body, s.Changes.Breaking.Commit, s.Changes.Breaking.Keywords,
+		s.Changes.Directives.SkipMarkers, s.Changes.Directives.Keyword,
+		s.Jira.URL, s.Jira.User, strings.Repeat("*", len(s.Jira.Token)), s.Jira.KeyRegex, s.Jira.Sources,
+		s.Hooks.PreGenerate, s.Hooks.PostRender, s.Hooks.PostWrite,
+		s.Sinks,
+		s.Content.ReleaseURL, s.Content.Contributors, s.Content.Summary, s.Content.TagMessage, s.Content.Stats,
 	)
 }
@@ -7,6 +7,31 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGrouping_Chain(t *testing.T) {
+	tests := []struct {
+		name          string
+		grouping      Grouping
+		expectedChain []Grouping
+	}{
+		{
+			name:          "Single",
+			grouping:      GroupingLabel,
+			expectedChain: []Grouping{GroupingLabel},
+		},
+		{
+			name:          "Chain",
+			grouping:      Grouping("milestone > label>scope"),
+			expectedChain: []Grouping{GroupingMilestone, GroupingLabel, GroupingScope},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedChain, tc.grouping.Chain())
+		})
+	}
+}
+
 func TestIssues_LabelGroups(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -26,14 +51,48 @@ func TestIssues_LabelGroups(t *testing.T) {
 				SecurityLabels:    []string{"security"},
 			},
 			expectedLabelGroups: []LabelGroup{
-				{Title: "Release Summary", Labels: []string{"summary", "release-summary"}},
-				{Title: "Removed", Labels: []string{"removed"}},
-				{Title: "Breaking Changes", Labels: []string{"breaking", "backward-incompatible"}},
-				{Title: "Deprecated", Labels: []string{"deprecated"}},
-				{Title: "New Features", Labels: []string{"feature"}},
-				{Title: "Enhancements", Labels: []string{"enhancement"}},
-				{Title: "Fixed Bugs", Labels: []string{"bug"}},
-				{Title: "Security Fixes", Labels: []string{"security"}},
+				{Title: "Release Summary", Labels: []string{"summary", "release-summary"}, Order: 10},
+				{Title: "Removed", Labels: []string{"removed"}, Order: 20},
+				{Title: "Breaking Changes", Labels: []string{"breaking", "backward-incompatible"}, Order: 30},
+				{Title: "Deprecated", Labels: []string{"deprecated"}, Order: 40},
+				{Title: "New Features", Labels: []string{"feature"}, Order: 50},
+				{Title: "Enhancements", Labels: []string{"enhancement"}, Order: 60},
+				{Title: "Fixed Bugs", Labels: []string{"bug"}, Order: 70},
+				{Title: "Security Fixes", Labels: []string{"security"}, Order: 80},
+			},
+		},
+		{
+			name: "CustomGroups",
+			issues: Issues{
+				FeatureLabels: []string{"feature"},
+				BugLabels:     []string{"bug"},
+				Groups: []LabelGroup{
+					{Title: "Fixed Bugs", Labels: []string{"bug", "defect"}, Order: 10, Emoji: ":bug:"},
+					{Title: "Performance", LabelRegex: "^perf", Order: 55, Emoji: ":zap:"},
+					{Title: "Documentation", Labels: []string{"docs"}},
+				},
+			},
+			expectedLabelGroups: []LabelGroup{
+				{Title: "Fixed Bugs", Labels: []string{"bug", "defect"}, Order: 10, Emoji: ":bug:"},
+				{Title: "New Features", Labels: []string{"feature"}, Order: 50},
+				{Title: "Performance", LabelRegex: "^perf", Order: 55, Emoji: ":zap:"},
+				{Title: "Documentation", Labels: []string{"docs"}},
+			},
+		},
+		{
+			name: "ReplaceGroups",
+			issues: Issues{
+				FeatureLabels: []string{"feature"},
+				BugLabels:     []string{"bug"},
+				Groups: []LabelGroup{
+					{Title: "Fehlerbehebungen", Labels: []string{"bug"}, Order: 2},
+					{Title: "Neue Funktionen", Labels: []string{"feature"}, Order: 1},
+				},
+				ReplaceGroups: true,
+			},
+			expectedLabelGroups: []LabelGroup{
+				{Title: "Neue Funktionen", Labels: []string{"feature"}, Order: 1},
+				{Title: "Fehlerbehebungen", Labels: []string{"bug"}, Order: 2},
 			},
 		},
 	}
@@ -66,14 +125,48 @@ func TestMerges_LabelGroups(t *testing.T) {
 				SecurityLabels:    []string{"security"},
 			},
 			expectedLabelGroups: []LabelGroup{
-				{Title: "Release Summary", Labels: []string{"summary", "release-summary"}},
-				{Title: "Removed", Labels: []string{"removed"}},
-				{Title: "Breaking Changes", Labels: []string{"breaking", "backward-incompatible"}},
-				{Title: "Deprecated", Labels: []string{"deprecated"}},
-				{Title: "New Features", Labels: []string{"feature"}},
-				{Title: "Enhancements", Labels: []string{"enhancement"}},
-				{Title: "Fixed Bugs", Labels: []string{"bug"}},
-				{Title: "Security Fixes", Labels: []string{"security"}},
+				{Title: "Release Summary", Labels: []string{"summary", "release-summary"}, Order: 10},
+				{Title: "Removed", Labels: []string{"removed"}, Order: 20},
+				{Title: "Breaking Changes", Labels: []string{"breaking", "backward-incompatible"}, Order: 30},
+				{Title: "Deprecated", Labels: []string{"deprecated"}, Order: 40},
+				{Title: "New Features", Labels: []string{"feature"}, Order: 50},
+				{Title: "Enhancements", Labels: []string{"enhancement"}, Order: 60},
+				{Title: "Fixed Bugs", Labels: []string{"bug"}, Order: 70},
+				{Title: "Security Fixes", Labels: []string{"security"}, Order: 80},
+			},
+		},
+		{
+			name: "CustomGroups",
+			merges: Merges{
+				FeatureLabels: []string{"feature"},
+				BugLabels:     []string{"bug"},
+				Groups: []LabelGroup{
+					{Title: "Fixed Bugs", Labels: []string{"bug", "defect"}, Order: 10, Emoji: ":bug:"},
+					{Title: "Performance", LabelRegex: "^perf", Order: 55, Emoji: ":zap:"},
+					{Title: "Documentation", Labels: []string{"docs"}},
+				},
+			},
+			expectedLabelGroups: []LabelGroup{
+				{Title: "Fixed Bugs", Labels: []string{"bug", "defect"}, Order: 10, Emoji: ":bug:"},
+				{Title: "New Features", Labels: []string{"feature"}, Order: 50},
+				{Title: "Performance", LabelRegex: "^perf", Order: 55, Emoji: ":zap:"},
+				{Title: "Documentation", Labels: []string{"docs"}},
+			},
+		},
+		{
+			name: "ReplaceGroups",
+			merges: Merges{
+				FeatureLabels: []string{"feature"},
+				BugLabels:     []string{"bug"},
+				Groups: []LabelGroup{
+					{Title: "Fehlerbehebungen", Labels: []string{"bug"}, Order: 2},
+					{Title: "Neue Funktionen", Labels: []string{"feature"}, Order: 1},
+				},
+				ReplaceGroups: true,
+			},
+			expectedLabelGroups: []LabelGroup{
+				{Title: "Neue Funktionen", Labels: []string{"feature"}, Order: 1},
+				{Title: "Fehlerbehebungen", Labels: []string{"bug"}, Order: 2},
 			},
 		},
 	}
@@ -124,6 +217,7 @@ func TestDefault(t *testing.T) {
 	spec := Default()
 
 	assert.NotNil(t, spec)
+	assert.Equal(t, "", spec.Repo.Remote)
 	assert.Equal(t, Platform(""), spec.Repo.Platform)
 	assert.Equal(t, "", spec.Repo.Path)
 	assert.Equal(t, "access-token", spec.Repo.AccessToken)
@@ -131,15 +225,27 @@ func TestDefault(t *testing.T) {
 	assert.Equal(t, "", spec.General.Base)
 	assert.Equal(t, false, spec.General.Print)
 	assert.Equal(t, false, spec.General.Verbose)
+	assert.False(t, spec.General.JSON)
+	assert.Equal(t, "", spec.General.Provider)
 	assert.Equal(t, "", spec.Tags.From)
 	assert.Equal(t, "", spec.Tags.To)
 	assert.Equal(t, "", spec.Tags.Future)
 	assert.Equal(t, []string{}, spec.Tags.Exclude)
+	assert.Equal(t, false, spec.Tags.Unreleased)
+	assert.Equal(t, PrereleaseSeparate, spec.Tags.Prerelease)
+	assert.Equal(t, false, spec.Tags.PrereleaseOrigin)
+	assert.Equal(t, TagDateCommit, spec.Tags.Date)
 	assert.Equal(t, "", spec.Tags.ExcludeRegex)
 	assert.Equal(t, SelectionAll, spec.Issues.Selection)
 	assert.Nil(t, spec.Issues.IncludeLabels)
 	assert.Equal(t, []string{"duplicate", "invalid", "question", "wontfix"}, spec.Issues.ExcludeLabels)
+	assert.Nil(t, spec.Issues.IncludeAuthors)
+	assert.Nil(t, spec.Issues.ExcludeAuthors)
+	assert.Equal(t, "", spec.Issues.ExcludeAuthorsRegex)
+	assert.Equal(t, BotsInclude, spec.Issues.Bots)
+	assert.Equal(t, "", spec.Issues.Filter)
 	assert.Equal(t, GroupingLabel, spec.Issues.Grouping)
+	assert.Equal(t, "area/", spec.Issues.ScopePrefix)
 	assert.Equal(t, []string{"summary", "release-summary"}, spec.Issues.SummaryLabels)
 	assert.Equal(t, []string{"removed"}, spec.Issues.RemovedLabels)
 	assert.Equal(t, []string{"breaking", "backward-incompatible"}, spec.Issues.BreakingLabels)
@@ -148,11 +254,20 @@ func TestDefault(t *testing.T) {
 	assert.Equal(t, []string{"enhancement"}, spec.Issues.EnhancementLabels)
 	assert.Equal(t, []string{"bug"}, spec.Issues.BugLabels)
 	assert.Equal(t, []string{"security"}, spec.Issues.SecurityLabels)
+	assert.Nil(t, spec.Issues.Groups)
+	assert.False(t, spec.Issues.ReplaceGroups)
 	assert.Equal(t, SelectionAll, spec.Merges.Selection)
 	assert.Equal(t, "", spec.Merges.Branch)
 	assert.Nil(t, spec.Merges.IncludeLabels)
 	assert.Nil(t, spec.Merges.ExcludeLabels)
+	assert.Nil(t, spec.Merges.IncludeAuthors)
+	assert.Nil(t, spec.Merges.ExcludeAuthors)
+	assert.Equal(t, "", spec.Merges.ExcludeAuthorsRegex)
+	assert.Equal(t, BotsInclude, spec.Merges.Bots)
+	assert.Equal(t, "", spec.Merges.Filter)
+	assert.False(t, spec.Merges.ReleaseNotes)
 	assert.Equal(t, GroupingSimple, spec.Merges.Grouping)
+	assert.Equal(t, "area/", spec.Merges.ScopePrefix)
 	assert.Equal(t, []string{}, spec.Merges.SummaryLabels)
 	assert.Equal(t, []string{}, spec.Merges.RemovedLabels)
 	assert.Equal(t, []string{}, spec.Merges.BreakingLabels)
@@ -161,7 +276,33 @@ func TestDefault(t *testing.T) {
 	assert.Equal(t, []string{}, spec.Merges.EnhancementLabels)
 	assert.Equal(t, []string{}, spec.Merges.BugLabels)
 	assert.Equal(t, []string{}, spec.Merges.SecurityLabels)
+	assert.Nil(t, spec.Merges.Groups)
+	assert.False(t, spec.Merges.ReplaceGroups)
+	assert.Equal(t, SelectionNone, spec.Commits.Selection)
+	assert.True(t, spec.Commits.ExcludeMerges)
+	assert.True(t, spec.Commits.ExcludeBots)
+	assert.Equal(t, []string{"[skip changelog]", "[changelog skip]"}, spec.Commits.SkipMarkers)
+	assert.Equal(t, DedupNone, spec.Changes.Dedup)
+	assert.Equal(t, LayoutSplit, spec.Changes.Layout)
+	assert.Equal(t, false, spec.Changes.Breaking.Title)
+	assert.Equal(t, false, spec.Changes.Breaking.Body)
+	assert.Equal(t, false, spec.Changes.Breaking.Commit)
+	assert.Equal(t, []string{"BREAKING CHANGE", "BREAKING-CHANGE"}, spec.Changes.Breaking.Keywords)
+	assert.Equal(t, []string{}, spec.Changes.Directives.SkipMarkers)
+	assert.Equal(t, "", spec.Changes.Directives.Keyword)
+	assert.Equal(t, "", spec.Jira.URL)
+	assert.Equal(t, "", spec.Jira.User)
+	assert.Equal(t, `\b[A-Z][A-Z0-9]+-[0-9]+\b`, spec.Jira.KeyRegex)
+	assert.Equal(t, []string{"title", "branch"}, spec.Jira.Sources)
+	assert.Nil(t, spec.Hooks.PreGenerate)
+	assert.Nil(t, spec.Hooks.PostRender)
+	assert.Nil(t, spec.Hooks.PostWrite)
+	assert.Nil(t, spec.Sinks)
 	assert.Equal(t, "", spec.Content.ReleaseURL)
+	assert.Equal(t, false, spec.Content.Contributors)
+	assert.Equal(t, false, spec.Content.Summary)
+	assert.Equal(t, false, spec.Content.TagMessage)
+	assert.False(t, spec.Content.Stats)
 }
 
 func TestSpec_FromFile(t *testing.T) {
@@ -212,6 +353,8 @@ func TestSpec_FromFile(t *testing.T) {
 					From:         "",
 					To:           "",
 					Future:       "",
+					Prerelease:   PrereleaseSeparate,
+					Date:         TagDateCommit,
 					Exclude:      []string{},
 					ExcludeRegex: "",
 				},
@@ -219,7 +362,11 @@ func TestSpec_FromFile(t *testing.T) {
 					Selection:         SelectionLabeled,
 					IncludeLabels:     nil,
 					ExcludeLabels:     []string{"duplicate", "invalid", "question", "wontfix"},
+					IncludeAuthors:    nil,
+					ExcludeAuthors:    nil,
+					Bots:              BotsInclude,
 					Grouping:          GroupingMilestone,
+					ScopePrefix:       "area/",
 					SummaryLabels:     []string{"summary", "release-summary"},
 					RemovedLabels:     []string{"removed"},
 					BreakingLabels:    []string{"breaking", "backward-incompatible"},
@@ -234,7 +381,11 @@ func TestSpec_FromFile(t *testing.T) {
 					Branch:            "production",
 					IncludeLabels:     nil,
 					ExcludeLabels:     nil,
+					IncludeAuthors:    nil,
+					ExcludeAuthors:    nil,
+					Bots:              BotsInclude,
 					Grouping:          GroupingSimple,
+					ScopePrefix:       "area/",
 					SummaryLabels:     []string{},
 					RemovedLabels:     []string{},
 					BreakingLabels:    []string{},
@@ -244,8 +395,31 @@ func TestSpec_FromFile(t *testing.T) {
 					BugLabels:         []string{},
 					SecurityLabels:    []string{},
 				},
+				Commits: Commits{
+					Selection:     SelectionNone,
+					ExcludeMerges: true,
+					ExcludeBots:   true,
+					SkipMarkers:   []string{"[skip changelog]", "[changelog skip]"},
+				},
+				Changes: Changes{
+					Dedup:  DedupNone,
+					Layout: LayoutSplit,
+					Breaking: Breaking{
+						Keywords: []string{"BREAKING CHANGE", "BREAKING-CHANGE"},
+					},
+					Directives: Directives{
+						SkipMarkers: []string{},
+						Keyword:     "",
+					},
+				},
+				Jira: Jira{
+					KeyRegex: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+					Sources:  []string{"title", "branch"},
+				},
 				Content: Content{
-					ReleaseURL: "",
+					ReleaseURL:   "",
+					Contributors: false,
+					Summary:      false,
 				},
 			},
 		},
@@ -257,28 +431,38 @@ func TestSpec_FromFile(t *testing.T) {
 				Help:    false,
 				Version: false,
 				Repo: Repo{
-					Platform:    Platform(""),
-					Path:        "",
+					Remote:      "upstream",
+					Platform:    PlatformGitHub,
+					Path:        "octocat/Hello-World",
 					AccessToken: "",
 				},
 				General: General{
-					File:    "RELEASE-NOTES.md",
-					Base:    "SUMMARY-NOTES.md",
-					Print:   true,
-					Verbose: true,
+					File:     "RELEASE-NOTES.md",
+					Base:     "SUMMARY-NOTES.md",
+					Print:    true,
+					Verbose:  true,
+					Provider: "jira",
 				},
 				Tags: Tags{
-					From:         "",
-					To:           "",
-					Future:       "",
-					Exclude:      []string{"prerelease", "candidate"},
-					ExcludeRegex: `(.*)-(alpha|beta)`,
+					From:             "",
+					To:               "",
+					Future:           "",
+					Unreleased:       true,
+					Prerelease:       PrereleaseRollup,
+					PrereleaseOrigin: true,
+					Date:             TagDateTag,
+					Exclude:          []string{"prerelease", "candidate"},
+					ExcludeRegex:     `(.*)-(alpha|beta)`,
 				},
 				Issues: Issues{
 					Selection:         SelectionLabeled,
 					IncludeLabels:     []string{"breaking", "bug", "defect", "deprecated", "enhancement", "feature", "highlight", "improvement", "incompatible", "privacy", "removed", "security", "summary"},
 					ExcludeLabels:     []string{"documentation", "duplicate", "invalid", "question", "wontfix"},
-					Grouping:          GroupingMilestone,
+					IncludeAuthors:    nil,
+					ExcludeAuthors:    []string{"octodog"},
+					Bots:              BotsExclude,
+					Grouping:          Grouping("milestone>label"),
+					ScopePrefix:       "area/",
 					SummaryLabels:     []string{"summary", "highlight"},
 					RemovedLabels:     []string{"removed"},
 					BreakingLabels:    []string{"breaking", "incompatible"},
@@ -287,24 +471,86 @@ func TestSpec_FromFile(t *testing.T) {
 					EnhancementLabels: []string{"enhancement", "improvement"},
 					BugLabels:         []string{"bug", "defect"},
 					SecurityLabels:    []string{"security", "privacy"},
+					Groups: []LabelGroup{
+						{Title: "Infrastructure", LabelRegex: "^infra"},
+					},
+					ReplaceGroups: true,
 				},
 				Merges: Merges{
-					Selection:         SelectionLabeled,
-					Branch:            "production",
-					IncludeLabels:     []string{"breaking", "bug", "defect", "deprecated", "enhancement", "feature", "highlight", "improvement", "incompatible", "privacy", "removed", "security", "summary"},
-					ExcludeLabels:     []string{"documentation", "duplicate", "invalid", "question", "wontfix"},
-					Grouping:          GroupingLabel,
-					SummaryLabels:     []string{"summary", "highlight"},
-					RemovedLabels:     []string{"removed"},
-					BreakingLabels:    []string{"breaking", "incompatible"},
-					DeprecatedLabels:  []string{"deprecated"},
-					FeatureLabels:     []string{"feature"},
-					EnhancementLabels: []string{"enhancement", "improvement"},
-					BugLabels:         []string{"bug", "defect"},
-					SecurityLabels:    []string{"security", "privacy"},
+					Selection:           SelectionLabeled,
+					Branch:              "production",
+					IncludeLabels:       []string{"breaking", "bug", "defect", "deprecated", "enhancement", "feature", "highlight", "improvement", "incompatible", "privacy", "removed", "security", "summary"},
+					ExcludeLabels:       []string{"documentation", "duplicate", "invalid", "question", "wontfix"},
+					IncludeAuthors:      nil,
+					ExcludeAuthors:      []string{"octodog"},
+					ExcludeAuthorsRegex: `^dependabot`,
+					Bots:                BotsCollapse,
+					Filter:              `title!~"^docs"`,
+					ReleaseNotes:        true,
+					Grouping:            Grouping("label>scope"),
+					ScopePrefix:         "scope/",
+					SummaryLabels:       []string{"summary", "highlight"},
+					RemovedLabels:       []string{"removed"},
+					BreakingLabels:      []string{"breaking", "incompatible"},
+					DeprecatedLabels:    []string{"deprecated"},
+					FeatureLabels:       []string{"feature"},
+					EnhancementLabels:   []string{"enhancement", "improvement"},
+					BugLabels:           []string{"bug", "defect"},
+					SecurityLabels:      []string{"security", "privacy"},
+					Groups: []LabelGroup{
+						{Title: "Performance", Labels: []string{"performance"}, LabelRegex: "^perf", Order: 65, Emoji: ":zap:"},
+						{Title: "Documentation", Labels: []string{"docs", "documentation"}},
+					},
+				},
+				Commits: Commits{
+					Selection:     SelectionAll,
+					ExcludeMerges: false,
+					ExcludeBots:   true,
+					SkipMarkers:   []string{"[skip changelog]", "[no changelog]"},
+				},
+				Changes: Changes{
+					Dedup:  DedupBoth,
+					Layout: LayoutUnified,
+					Breaking: Breaking{
+						Title:    true,
+						Body:     true,
+						Commit:   true,
+						Keywords: []string{"BREAKING CHANGE", "MIGRATION"},
+					},
+					Directives: Directives{
+						SkipMarkers: []string{"[skip changelog]", "[no changelog]"},
+						Keyword:     "release-note",
+					},
+				},
+				Jira: Jira{
+					URL:      "https://example.atlassian.net",
+					User:     "octocat@example.com",
+					KeyRegex: `\b(PROJ|OPS)-[0-9]+\b`,
+					Sources:  []string{"title", "body", "branch"},
+				},
+				Hooks: Hooks{
+					PreGenerate: []Hook{
+						{Command: "./scripts/bump-version.sh"},
+					},
+					PostRender: []Hook{
+						{Template: "<!-- Generated by changelog -->\n{{ .Content }}"},
+						{Command: "./scripts/notify.sh"},
+					},
+					PostWrite: []Hook{
+						{Command: "npx prettier --write CHANGELOG.md"},
+					},
+				},
+				Sinks: []Sink{
+					{Type: SinkSlack, URL: "${SLACK_WEBHOOK_URL}"},
+					{Type: SinkTeams, URL: "${TEAMS_WEBHOOK_URL}", Template: "{{ range .Releases }}{{ .TagName }} {{ end }}"},
+					{Type: SinkWebhook, URL: "https://example.com/releases"},
 				},
 				Content: Content{
-					ReleaseURL: "https://storage.artifactory.com/project/releases/{tag}",
+					ReleaseURL:   "https://storage.artifactory.com/project/releases/{tag}",
+					Contributors: true,
+					Summary:      true,
+					TagMessage:   true,
+					Stats:        true,
 				},
 			},
 		},
@@ -326,6 +572,283 @@ func TestSpec_FromFile(t *testing.T) {
 	}
 }
 
+func TestSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          Spec
+		expectedError string
+	}{
+		{
+			name:          "Default",
+			spec:          Default(),
+			expectedError: "",
+		},
+		{
+			name: "RepoPlatformWithoutPath",
+			spec: Spec{
+				Repo: Repo{
+					Platform: PlatformGitHub,
+				},
+			},
+			expectedError: "repo platform cannot be used without path",
+		},
+		{
+			name: "RepoPathWithoutPlatform",
+			spec: Spec{
+				Repo: Repo{
+					Path: "octocat/Hello-World",
+				},
+			},
+			expectedError: "repo path cannot be used without platform or provider",
+		},
+		{
+			name: "RepoPathWithProvider",
+			spec: Spec{
+				Repo: Repo{
+					Path: "octocat/Hello-World",
+				},
+				General: General{
+					Provider: "jira",
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "FutureTagWithUnreleased",
+			spec: Spec{
+				Tags: Tags{
+					Future:     "v0.1.0",
+					Unreleased: true,
+				},
+			},
+			expectedError: "future-tag cannot be used with unreleased",
+		},
+		{
+			name: "LabeledCommits",
+			spec: Spec{
+				Commits: Commits{
+					Selection: SelectionLabeled,
+				},
+			},
+			expectedError: "commits selection cannot be labeled",
+		},
+		{
+			name: "InvalidJiraKeyRegex",
+			spec: Spec{
+				Jira: Jira{
+					URL:      "https://example.atlassian.net",
+					KeyRegex: "[A-Z",
+				},
+			},
+			expectedError: "jira key-regex: error parsing regexp: missing closing ]: `[A-Z`",
+		},
+		{
+			name: "InvalidJiraSource",
+			spec: Spec{
+				Jira: Jira{
+					URL:      "https://example.atlassian.net",
+					KeyRegex: `\b[A-Z][A-Z0-9]+-[0-9]+\b`,
+					Sources:  []string{"title", "commit"},
+				},
+			},
+			expectedError: "invalid jira source: commit",
+		},
+		{
+			name: "EmptyHook",
+			spec: Spec{
+				Hooks: Hooks{
+					PreGenerate: []Hook{{}},
+				},
+			},
+			expectedError: "pre-generate hook: command or template is required",
+		},
+		{
+			name: "HookWithCommandAndTemplate",
+			spec: Spec{
+				Hooks: Hooks{
+					PostRender: []Hook{{Command: "cat", Template: "{{ .Content }}"}},
+				},
+			},
+			expectedError: "post-render hook: command and template cannot be used together",
+		},
+		{
+			name: "PostWriteTemplate",
+			spec: Spec{
+				Hooks: Hooks{
+					PostWrite: []Hook{{Template: "{{ .Content }}"}},
+				},
+			},
+			expectedError: "post-write hook: template is only supported for post-render",
+		},
+		{
+			name: "InvalidHookTemplate",
+			spec: Spec{
+				Hooks: Hooks{
+					PostRender: []Hook{{Template: "{{ .Content"}},
+				},
+			},
+			expectedError: "post-render hook: template: post-render:1: unclosed action",
+		},
+		{
+			name: "InvalidSinkType",
+			spec: Spec{
+				Sinks: []Sink{{Type: "discord", URL: "https://example.com"}},
+			},
+			expectedError: "invalid sink type: discord",
+		},
+		{
+			name: "SinkWithoutURL",
+			spec: Spec{
+				Sinks: []Sink{{Type: SinkSlack}},
+			},
+			expectedError: "slack sink: url is required",
+		},
+		{
+			name: "InvalidSinkTemplate",
+			spec: Spec{
+				Sinks: []Sink{{Type: SinkTeams, URL: "https://example.com", Template: "{{ .Content"}},
+			},
+			expectedError: "teams sink: template: teams:1: unclosed action",
+		},
+		{
+			name: "ValidFilters",
+			spec: Spec{
+				Issues: Issues{
+					Filter: `label:bug && !label:internal`,
+				},
+				Merges: Merges{
+					Filter: `author!~"bot"`,
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "InvalidIssuesFilter",
+			spec: Spec{
+				Issues: Issues{
+					Filter: `label:bug &&`,
+				},
+			},
+			expectedError: `issues filter: invalid filter expression: unexpected "end of expression" at position 12`,
+		},
+		{
+			name: "MissingGroupTitle",
+			spec: Spec{
+				Issues: Issues{
+					Groups: []LabelGroup{
+						{Labels: []string{"performance"}},
+					},
+				},
+			},
+			expectedError: "issues group: title is required",
+		},
+		{
+			name: "InvalidGroupLabelRegex",
+			spec: Spec{
+				Merges: Merges{
+					Groups: []LabelGroup{
+						{Title: "Performance", LabelRegex: "["},
+					},
+				},
+			},
+			expectedError: "merges group \"Performance\": error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "InvalidIssuesGrouping",
+			spec: Spec{
+				Issues: Issues{
+					Grouping: Grouping("milestone>author"),
+				},
+			},
+			expectedError: "issues invalid grouping: milestone>author",
+		},
+		{
+			name: "InvalidMergesGrouping",
+			spec: Spec{
+				Merges: Merges{
+					Grouping: Grouping("simple>label"),
+				},
+			},
+			expectedError: "merges invalid grouping: simple cannot be chained",
+		},
+		{
+			name: "InvalidMergesFilter",
+			spec: Spec{
+				Merges: Merges{
+					Filter: `state:merged`,
+				},
+			},
+			expectedError: `merges filter: invalid filter expression: unknown field "state" at position 0`,
+		},
+		{
+			name: "InvalidChangesDedup",
+			spec: Spec{
+				Changes: Changes{
+					Dedup: Dedup("all"),
+				},
+			},
+			expectedError: "changes invalid dedup: all",
+		},
+		{
+			name: "InvalidIssuesBots",
+			spec: Spec{
+				Issues: Issues{
+					Bots: Bots("hide"),
+				},
+			},
+			expectedError: "issues invalid bots: hide",
+		},
+		{
+			name: "InvalidMergesBots",
+			spec: Spec{
+				Merges: Merges{
+					Bots: Bots("collapsed"),
+				},
+			},
+			expectedError: "merges invalid bots: collapsed",
+		},
+		{
+			name: "InvalidChangesLayout",
+			spec: Spec{
+				Changes: Changes{
+					Layout: Layout("merged"),
+				},
+			},
+			expectedError: "changes invalid layout: merged",
+		},
+		{
+			name: "InvalidTagsDate",
+			spec: Spec{
+				Tags: Tags{
+					Date: TagDate("foo"),
+				},
+			},
+			expectedError: "tags invalid date: foo",
+		},
+		{
+			name: "InvalidTagsPrerelease",
+			spec: Spec{
+				Tags: Tags{
+					Prerelease: Prerelease("merge"),
+				},
+			},
+			expectedError: "tags invalid prerelease: merge",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestSpec_WithRepo(t *testing.T) {
 	tests := []struct {
 		name         string